@@ -0,0 +1,280 @@
+package eulerlib
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// Mobius returns μ(n): 0 if n has a squared prime factor, otherwise (-1)^k
+// where k is the number of prime factors of n
+func Mobius[E SignedInteger](n E) E {
+	if n < 1 {
+		return 0
+	}
+	ps, es := primePowers(n)
+	for _, e := range es {
+		if e > 1 {
+			return 0
+		}
+	}
+	if len(ps)%2 == 1 {
+		return -1
+	}
+	return 1
+}
+
+// ListMobius returns a slice where the value at every index i is μ(i), for all i <= n.
+// It uses a linear sieve.
+func ListMobius[E SignedInteger](n E) []E {
+	if n < 0 {
+		panic("n must be non-negative")
+	}
+	res := make([]E, n+1)
+	if n == 0 {
+		return res
+	}
+	res[1] = 1
+	composite := make([]bool, n+1)
+	primes := []E{}
+	for i := E(2); i <= n; i++ {
+		if !composite[i] {
+			primes = append(primes, i)
+			res[i] = -1
+		}
+		for _, p := range primes {
+			if i*p > n {
+				break
+			}
+			composite[i*p] = true
+			if i%p == 0 {
+				res[i*p] = 0
+				break
+			}
+			res[i*p] = -res[i]
+		}
+	}
+	return res
+}
+
+// DirichletConvolution returns the table of (f * g)(n) = Σ_{d|n} f(d)·g(n/d).
+// The tables are indexed by n, index 0 is ignored, and the result is as long as
+// the shorter of f and g. It takes O(n log n) time.
+func DirichletConvolution[E RealNumber](f, g []E) []E {
+	n := min(len(f), len(g))
+	res := make([]E, n)
+	for d := 1; d < n; d++ {
+		if f[d] == 0 {
+			continue
+		}
+		for k, m := 1, d; m < n; k, m = k+1, m+d {
+			res[m] += f[d] * g[k]
+		}
+	}
+	return res
+}
+
+// MobiusInversion recovers f from the table g(n) = Σ_{d|n} f(d), using
+// f(n) = Σ_{d|n} μ(d)·g(n/d). Index 0 is ignored.
+func MobiusInversion[E RealNumber](g []E) []E {
+	if len(g) == 0 {
+		return []E{}
+	}
+	mu := ListMobius(len(g) - 1)
+	m := make([]E, len(g))
+	for i, v := range mu {
+		m[i] = E(v)
+	}
+	return DirichletConvolution(m, g)
+}
+
+// HyperbolaSum returns Σ_{n<=x} (f * g)(n) with the Dirichlet hyperbola method,
+// where F and G are the summatory functions of f and g.
+// It calls each of f, g, F and G O(sqrt(x)) times.
+func HyperbolaSum(x int64, f, g, F, G func(int64) int64) int64 {
+	if x < 1 {
+		return 0
+	}
+	s := isqrt(x)
+	res := -F(s) * G(s)
+	for a := int64(1); a <= s; a++ {
+		res += f(a)*G(x/a) + g(a)*F(x/a)
+	}
+	return res
+}
+
+// DivisorSummatory returns D(x) = Σ_{n<=x} d(n), the total number of divisors
+// of all integers up to x, in O(sqrt(x)) time
+func DivisorSummatory(x int64) int64 {
+	one := func(int64) int64 { return 1 }
+	id := func(n int64) int64 { return n }
+	return HyperbolaSum(x, one, one, id, id)
+}
+
+// Mertens returns M(x) = Σ_{n<=x} μ(n) in roughly O(x^(2/3)) time and memory,
+// using M(x) = 1 - Σ_{i=2}^{x} M(x/i) memoized on the values of floor(x/i)
+func Mertens(x int64) int64 {
+	if x < 1 {
+		return 0
+	}
+	limit := summatorySieveLimit(x)
+	small := ListMobius(limit)
+	for i := int64(1); i <= limit; i++ {
+		small[i] += small[i-1]
+	}
+	// every argument reached from x is floor(x/k), so large values are keyed by k
+	large, done := make([]int64, x/limit+1), make([]bool, x/limit+1)
+	var m func(v int64) int64
+	m = func(v int64) int64 {
+		if v <= limit {
+			return small[v]
+		}
+		if done[x/v] {
+			return large[x/v]
+		}
+		res := int64(1)
+		for i := int64(2); i <= v; {
+			q := v / i
+			j := v / q
+			res -= (j - i + 1) * m(q)
+			i = j + 1
+		}
+		large[x/v], done[x/v] = res, true
+		return res
+	}
+	return m(x)
+}
+
+// TotientSum returns Φ(x) = Σ_{n<=x} φ(n) exactly in roughly O(x^(2/3)) time,
+// using Φ(x) = x(x+1)/2 - Σ_{i=2}^{x} Φ(x/i) memoized on the values of floor(x/i).
+// Φ(x) exceeds the int64 range for x above about 5·10^9, hence the big.Int result.
+func TotientSum(x int64) *big.Int {
+	if x < 1 {
+		return big.NewInt(0)
+	}
+	limit := summatorySieveLimit(x)
+	small := ListTotients(limit)
+	for i := int64(1); i <= limit; i++ {
+		small[i] += small[i-1]
+	}
+	// the intermediate sums are kept modulo 2^128, which is exact since Φ(x) < 2^127
+	large, done := make([]uint128, x/limit+1), make([]bool, x/limit+1)
+	var phi func(v int64) uint128
+	phi = func(v int64) uint128 {
+		if v <= limit {
+			return uint128{0, uint64(small[v])}
+		}
+		if done[x/v] {
+			return large[x/v]
+		}
+		res := mul128(uint64(v), uint64(v+1)).rsh1()
+		for i := int64(2); i <= v; {
+			q := v / i
+			j := v / q
+			res = res.sub(phi(q).mul(uint64(j - i + 1)))
+			i = j + 1
+		}
+		large[x/v], done[x/v] = res, true
+		return res
+	}
+	return phi(x).big()
+}
+
+// TotientSumMod returns Φ(x) = Σ_{n<=x} φ(n) modulo m, see TotientSum
+func TotientSumMod(x, m int64) int64 {
+	if x < 1 {
+		return 0
+	}
+	limit := summatorySieveLimit(x)
+	small := ListTotients(limit)
+	for i := int64(1); i <= limit; i++ {
+		small[i] = (small[i-1] + small[i]) % m
+	}
+	large, done := make([]int64, x/limit+1), make([]bool, x/limit+1)
+	var phi func(v int64) int64
+	phi = func(v int64) int64 {
+		if v <= limit {
+			return small[v]
+		}
+		if done[x/v] {
+			return large[x/v]
+		}
+		res := new(big.Int).Mod(triangular(v), big.NewInt(m)).Int64()
+		for i := int64(2); i <= v; {
+			q := v / i
+			j := v / q
+			res = (res - mulMod((j-i+1)%m, phi(q), m) + m) % m
+			i = j + 1
+		}
+		large[x/v], done[x/v] = res, true
+		return res
+	}
+	return phi(x)
+}
+
+// summatorySieveLimit returns the size of the sieved prefix used by the
+// memoized summatory functions, about x^(2/3)
+func summatorySieveLimit(x int64) int64 {
+	c := math.Cbrt(float64(x))
+	return min(x, max(int64(c*c), isqrt(x)+1))
+}
+
+// triangular returns x(x+1)/2 as a big.Int
+func triangular(x int64) *big.Int {
+	return mul128(uint64(x), uint64(x+1)).rsh1().big()
+}
+
+// uint128 is an unsigned 128-bit integer with wrap-around arithmetic
+type uint128 struct {
+	hi, lo uint64
+}
+
+func mul128(a, b uint64) uint128 {
+	hi, lo := bits.Mul64(a, b)
+	return uint128{hi, lo}
+}
+
+func (u uint128) sub(v uint128) uint128 {
+	lo, borrow := bits.Sub64(u.lo, v.lo, 0)
+	hi, _ := bits.Sub64(u.hi, v.hi, borrow)
+	return uint128{hi, lo}
+}
+
+func (u uint128) mul(k uint64) uint128 {
+	hi, lo := bits.Mul64(u.lo, k)
+	return uint128{u.hi*k + hi, lo}
+}
+
+func (u uint128) rsh1() uint128 {
+	return uint128{u.hi >> 1, u.lo>>1 | u.hi<<63}
+}
+
+func (u uint128) big() *big.Int {
+	res := new(big.Int).SetUint64(u.hi)
+	res.Lsh(res, 64)
+	return res.Or(res, new(big.Int).SetUint64(u.lo))
+}
+
+// isqrt returns floor(sqrt(n)) for n >= 0, exact for the whole int64 range
+func isqrt(n int64) int64 {
+	r := int64(math.Sqrt(float64(n)))
+	for r > 0 && r > n/r {
+		r--
+	}
+	for r+1 <= n/(r+1) {
+		r++
+	}
+	return r
+}
+
+// mulMod returns a·b mod m for 0 <= a, b < m without overflowing
+func mulMod(a, b, m int64) int64 {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	_, rem := bits.Div64(hi, lo, uint64(m))
+	return int64(rem)
+}
+
+// addMod returns a+b mod m for 0 <= a, b < m without overflowing
+func addMod(a, b, m int64) int64 {
+	return int64((uint64(a) + uint64(b)) % uint64(m))
+}
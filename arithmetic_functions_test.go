@@ -0,0 +1,96 @@
+package eulerlib
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestListMobius(t *testing.T) {
+	got := ListMobius(30)
+	want := []int{0, 1, -1, -1, 0, -1, 1, -1, 0, 0, 1, -1, 0, -1, 1, 1, 0, -1, 0, -1, 0, 1, 1, -1, 0, 0, 1, 0, 0, -1, -1}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ListMobius(30)[%d] == %d, want %d", i, got[i], want[i])
+		}
+		if m := Mobius(i); m != want[i] {
+			t.Errorf("Mobius(%d) == %d, want %d", i, m, want[i])
+		}
+	}
+}
+
+func TestDirichletConvolution(t *testing.T) {
+	n := 1000
+	one := GenerateSlice(n+1, int64(1))
+	// 1 * 1 = d, and φ * 1 = id
+	d := DirichletConvolution(one, one)
+	phi := ListTotients(int64(n))
+	id := DirichletConvolution(phi, one)
+	for i := 1; i <= n; i++ {
+		if d[i] != CountDivisors(int64(i)) {
+			t.Errorf("(1 * 1)(%d) == %d, want %d", i, d[i], CountDivisors(int64(i)))
+		}
+		if id[i] != int64(i) {
+			t.Errorf("(φ * 1)(%d) == %d, want %d", i, id[i], i)
+		}
+	}
+	inv := MobiusInversion(id)
+	for i := 1; i <= n; i++ {
+		if inv[i] != phi[i] {
+			t.Errorf("MobiusInversion(id)[%d] == %d, want %d", i, inv[i], phi[i])
+		}
+	}
+}
+
+func TestDivisorSummatory(t *testing.T) {
+	n := int64(100000)
+	d := ListSigmaK(n, 0)
+	want := int64(0)
+	for i := int64(1); i <= n; i++ {
+		want += d[i]
+		if i%997 == 0 || i == n {
+			if got := DivisorSummatory(i); got != want {
+				t.Errorf("DivisorSummatory(%d) == %d, want %d", i, got, want)
+			}
+		}
+	}
+}
+
+func TestMertens(t *testing.T) {
+	testNums := []int64{1, 10, 100, 1000, 10000, 100000, 1000000, 10000000, 1000000000}
+	want := []int64{1, -1, 1, 2, -23, -48, 212, 1037, -222}
+	for i, num := range testNums {
+		got := Mertens(num)
+		if got != want[i] {
+			t.Errorf("Mertens(%d) == %d, want %d", num, got, want[i])
+		}
+	}
+}
+
+func TestTotientSum(t *testing.T) {
+	testNums := []int64{1, 10, 1000000, 1000000000}
+	want := []string{"1", "32", "303963552392", "303963551173008414"}
+	for i, num := range testNums {
+		got := TotientSum(num)
+		if got.String() != want[i] {
+			t.Errorf("TotientSum(%d) == %s, want %s", num, got, want[i])
+		}
+	}
+	x := int64(10000000000)
+	m := int64(1000000007)
+	exact := TotientSum(x)
+	if got := TotientSumMod(x, m); got != new(big.Int).Mod(exact, big.NewInt(m)).Int64() {
+		t.Errorf("TotientSumMod(%d, %d) == %d, want %s mod %d", x, m, got, exact, m)
+	}
+}
+
+func TestIsqrt(t *testing.T) {
+	testNums := []int64{0, 1, 3, 4, 99, 100, 999999999999, math.MaxInt64}
+	want := []int64{0, 1, 1, 2, 9, 10, 999999, 3037000499}
+	for i, num := range testNums {
+		got := isqrt(num)
+		if got != want[i] {
+			t.Errorf("isqrt(%d) == %d, want %d", num, got, want[i])
+		}
+	}
+}
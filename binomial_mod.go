@@ -0,0 +1,254 @@
+package eulerlib
+
+// lucasTableLimit is the largest prime for which BinomialModPrime and NewBinomialModTable precompute a factorial table
+const lucasTableLimit = 1 << 20
+
+// FactorialTable holds k! and 1/k! modulo a prime p for all k <= n,
+// so that binomial coefficients below n can be read off in O(1)
+type FactorialTable struct {
+	p       int64
+	fact    []int64
+	invFact []int64
+}
+
+// NewFactorialTable precomputes the factorials and inverse factorials of 0..n modulo the prime p
+// in O(n) time and a single modular inversion. It panics unless 0 <= n < p, because n! is
+// divisible by p otherwise.
+//
+// Example:
+// t := NewFactorialTable(1000000, 1000000007)
+// c := t.Binomial(1000000, 500000)
+func NewFactorialTable(n int, p int64) *FactorialTable {
+	if n < 0 || p < 2 || int64(n) >= p {
+		panic("NewFactorialTable requires 0 <= n < p")
+	}
+	fact := make([]int64, n+1)
+	invFact := make([]int64, n+1)
+	fact[0] = 1
+	for i := 1; i <= n; i++ {
+		fact[i] = mulMod(fact[i-1], int64(i), p)
+	}
+	invFact[n] = invMod(fact[n], p)
+	for i := n; i > 0; i-- {
+		invFact[i-1] = mulMod(invFact[i], int64(i), p)
+	}
+	return &FactorialTable{p, fact, invFact}
+}
+
+// Mod returns the prime modulus of the table
+func (t *FactorialTable) Mod() int64 {
+	return t.p
+}
+
+// Factorial returns k! mod p
+func (t *FactorialTable) Factorial(k int) int64 {
+	return t.fact[k]
+}
+
+// InvFactorial returns the inverse of k! modulo p
+func (t *FactorialTable) InvFactorial(k int) int64 {
+	return t.invFact[k]
+}
+
+// Inverse returns the inverse of k modulo p for 1 <= k <= n, as (k-1)!/k!
+func (t *FactorialTable) Inverse(k int) int64 {
+	if k < 1 {
+		panic("k must be positive")
+	}
+	return mulMod(t.fact[k-1], t.invFact[k], t.p)
+}
+
+// Binomial returns C(n, k) mod p for n within the table, and 0 if k is not in [0, n]
+func (t *FactorialTable) Binomial(n, k int) int64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	return mulMod(t.fact[n], mulMod(t.invFact[k], t.invFact[n-k], t.p), t.p)
+}
+
+// Lucas returns C(n, k) mod p for any n >= 0 by Lucas' theorem: C(n, k) is the product of
+// the binomials of the base p digits of n and k. Digit binomials within the table are read off
+// in O(1), so a table built with NewFactorialTable(p-1, p) answers every query in O(log n).
+// Larger digits take O(min(k, n-k)) multiplications each.
+func (t *FactorialTable) Lucas(n, k int64) int64 {
+	if n < 0 {
+		panic("n must be non-negative")
+	}
+	if k < 0 || k > n {
+		return 0
+	}
+	p := t.p
+	res := 1 % p
+	for n > 0 && res != 0 {
+		ni, ki := n%p, k%p
+		if ki > ni {
+			return 0
+		}
+		if ni < int64(len(t.fact)) {
+			res = mulMod(res, t.Binomial(int(ni), int(ki)), p)
+		} else {
+			res = mulMod(res, binomialProductMod(ni, ki, p), p)
+		}
+		n, k = n/p, k/p
+	}
+	return res
+}
+
+// BinomialModPrime returns C(n, k) mod p for a prime p and any n >= 0, using Lucas' theorem.
+// For n >= p and p up to 2^20 every call builds a factorial table of p entries, so repeated
+// queries should build it once with NewFactorialTable(p-1, p) and use its Lucas method.
+// For larger p each base p digit takes O(min(k, n-k)) multiplications.
+//
+// Example:
+// c := BinomialModPrime(1000000000000, 500000000000, 13)
+// // c == 0
+func BinomialModPrime(n, k, p int64) int64 {
+	if n < 0 || p < 2 {
+		panic("BinomialModPrime requires n >= 0 and a prime p")
+	}
+	table := &FactorialTable{p: p}
+	if p <= lucasTableLimit && n >= p {
+		table = NewFactorialTable(int(p-1), p)
+	}
+	return table.Lucas(n, k)
+}
+
+// BinomialModTable holds the tables needed for binomial coefficients modulo a fixed m,
+// so that a series of queries against the same modulus pays for them only once
+type BinomialModTable struct {
+	m     int64
+	parts []binomialPrimePower
+}
+
+// binomialPrimePower is the part of a BinomialModTable for one prime power p^e dividing m
+type binomialPrimePower struct {
+	p, pe int64
+	e     int
+	// fact is the factorial table for e == 1, empty for primes beyond lucasTableLimit
+	fact *FactorialTable
+	// units[i] is the product of all j <= i that are not divisible by p, modulo p^e, for e > 1
+	units []int64
+}
+
+// NewBinomialModTable precomputes the tables for BinomialModTable.Binomial modulo m >= 1:
+// a factorial table of p entries for every prime factor p up to 2^20 and a table of p^e
+// entries for every higher prime power p^e dividing m. It takes O(Σ p^e) time and memory.
+//
+// Example:
+// t := NewBinomialModTable(1000000000)
+// c := t.Binomial(1000, 500)
+// // c == 821216320
+func NewBinomialModTable(m int64) *BinomialModTable {
+	if m < 1 {
+		panic("modulus must be positive")
+	}
+	t := &BinomialModTable{m: m}
+	ps, es := primePowers(m)
+	for i, p := range ps {
+		part := binomialPrimePower{p: p, pe: Pow(p, int64(es[i])), e: es[i]}
+		switch {
+		case es[i] > 1:
+			part.units = make([]int64, part.pe)
+			part.units[0] = 1
+			for j := int64(1); j < part.pe; j++ {
+				part.units[j] = part.units[j-1]
+				if j%p != 0 {
+					part.units[j] = mulMod(part.units[j], j, part.pe)
+				}
+			}
+		case p <= lucasTableLimit:
+			part.fact = NewFactorialTable(int(p-1), p)
+		default:
+			part.fact = &FactorialTable{p: p}
+		}
+		t.parts = append(t.parts, part)
+	}
+	return t
+}
+
+// Mod returns the modulus of the table
+func (t *BinomialModTable) Mod() int64 {
+	return t.m
+}
+
+// Binomial returns C(n, k) mod m for any n >= 0. The prime factors of m use Lucas' theorem
+// and the higher prime powers Granville's generalization of it, and the results are combined
+// with the Chinese remainder theorem. Each query takes O(log n) steps per prime power, plus
+// O(min(k, n-k)) per base p digit for primes beyond 2^20.
+func (t *BinomialModTable) Binomial(n, k int64) int64 {
+	if n < 0 {
+		panic("n must be non-negative")
+	}
+	if k < 0 || k > n || t.m == 1 {
+		return 0
+	}
+	res, mod := int64(0), int64(1)
+	for _, part := range t.parts {
+		var r int64
+		if part.e == 1 {
+			r = part.fact.Lucas(n, k)
+		} else {
+			r = part.binomial(n, k)
+		}
+		res, mod = crt(res, mod, r, part.pe)
+	}
+	return res
+}
+
+// BinomialMod returns C(n, k) mod m for any modulus m >= 1, see BinomialModTable.
+// Every call builds the tables of NewBinomialModTable(m), which costs O(Σ p^e) over the
+// prime powers p^e of m with p^e up to 2^20 or e > 1, so repeated queries against the
+// same modulus should build the table once.
+//
+// Example:
+// c := BinomialMod(1000, 500, 1000000000)
+// // c == 821216320
+func BinomialMod(n, k, m int64) int64 {
+	if n < 0 || m < 1 {
+		panic("BinomialMod requires n >= 0 and m >= 1")
+	}
+	if k < 0 || k > n || m == 1 {
+		return 0
+	}
+	return NewBinomialModTable(m).Binomial(n, k)
+}
+
+// binomialProductMod returns C(n, k) mod p for 0 <= k <= n < p as the quotient of the
+// products of the k largest and the k smallest factors
+func binomialProductMod(n, k, p int64) int64 {
+	k = min(k, n-k)
+	num, den := 1%p, 1%p
+	for i := int64(1); i <= k; i++ {
+		num = mulMod(num, n-k+i, p)
+		den = mulMod(den, i, p)
+	}
+	return mulMod(num, invMod(den, p), p)
+}
+
+// binomial returns C(n, k) mod p^e. It writes every factorial as p^v·(x!)_p, where (x!)_p is
+// the product of the factors not divisible by p, and evaluates (x!)_p with the units table.
+func (part *binomialPrimePower) binomial(n, k int64) int64 {
+	p, pe := part.p, part.pe
+	v := KummerValuation(n, k, p)
+	if v >= int64(part.e) {
+		return 0
+	}
+	mul := func(a, b int64) int64 { return mulMod(a, b, pe) }
+	unitFactorial := func(x int64) int64 {
+		res := int64(1)
+		for ; x > 0; x /= p {
+			// x! splits into x/p^e full blocks of units, the rest and the multiples of p, which give (x/p)!
+			res = mul(res, mul(PowMonoid(part.units[pe-1], uint64(x/pe), 1, mul), part.units[x%pe]))
+		}
+		return res
+	}
+	res := mul(unitFactorial(n), invMod(mul(unitFactorial(k), unitFactorial(n-k)), pe))
+	return mul(res, Pow(p, v))
+}
+
+// crt returns the solution x mod m1·m2 of x ≡ a1 (mod m1) and x ≡ a2 (mod m2)
+// for coprime moduli, together with m1·m2
+func crt(a1, m1, a2, m2 int64) (int64, int64) {
+	t := mulMod(normMod(a2-a1, m2), invMod(m1%m2, m2), m2)
+	return a1 + m1*t, m1 * m2
+}
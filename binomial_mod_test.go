@@ -0,0 +1,110 @@
+package eulerlib
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFactorialTable(t *testing.T) {
+	const p = 1000000007
+	table := NewFactorialTable(1000000, p)
+	if got := table.Binomial(1000000, 500000); got != 996692777 {
+		t.Errorf("Binomial(10^6, 5·10^5) mod p == %d, want %d", got, 996692777)
+	}
+	for n := range 60 {
+		for k := -1; k <= n+1; k++ {
+			want := int64(0)
+			if k >= 0 && k <= n {
+				want = new(big.Int).Mod(Binomial(n, k), big.NewInt(p)).Int64()
+			}
+			if got := table.Binomial(n, k); got != want {
+				t.Errorf("Binomial(%d, %d) mod p == %d, want %d", n, k, got, want)
+			}
+		}
+	}
+	for _, k := range []int{1, 2, 12345, 999999} {
+		if got := mulMod(table.Inverse(k), int64(k), p); got != 1 {
+			t.Errorf("Inverse(%d)·%d mod p == %d, want 1", k, k, got)
+		}
+		if got := mulMod(table.Factorial(k), table.InvFactorial(k), p); got != 1 {
+			t.Errorf("Factorial(%d)·InvFactorial(%d) mod p == %d, want 1", k, k, got)
+		}
+	}
+}
+
+func TestBinomialModPrime(t *testing.T) {
+	tests := [][4]int64{
+		{821684558699168009, 154419227899243228, 3, 1},
+		{132934127510613454, 123002017343200060, 3, 2},
+		{173257035065763427, 101312726714217112, 101, 57},
+		{956233972489234194, 650659145024438061, 101, 81},
+		{808672119966204061, 988, 1000003, 734417},
+		{635604592051590324, 1545, 1000003, 832499},
+		{926565786049687736, 660, 1000000007, 704596577},
+		{886133337015645655, 2758, 1000000007, 266776318},
+		{1000000000000, 500000000000, 13, 0},
+		{10, 11, 7, 0},
+	}
+	for _, tt := range tests {
+		if got := BinomialModPrime(tt[0], tt[1], tt[2]); got != tt[3] {
+			t.Errorf("BinomialModPrime(%d, %d, %d) == %d, want %d", tt[0], tt[1], tt[2], got, tt[3])
+		}
+		table := &FactorialTable{p: tt[2]}
+		if tt[2] <= lucasTableLimit {
+			table = NewFactorialTable(int(tt[2]-1), tt[2])
+		}
+		if got := table.Lucas(tt[0], tt[1]); got != tt[3] {
+			t.Errorf("Lucas(%d, %d) mod %d == %d, want %d", tt[0], tt[1], tt[2], got, tt[3])
+		}
+	}
+}
+
+func TestBinomialMod(t *testing.T) {
+	moduli := []int64{1, 2, 8, 27, 1000, 142857, 69984, 2 * 3 * 5 * 7 * 11 * 13 * 17 * 19 * 23, 1000000007}
+	for _, m := range moduli {
+		for n := int64(0); n <= 120; n += 7 {
+			for k := int64(0); k <= n; k += 3 {
+				want := new(big.Int).Mod(Binomial(n, k), big.NewInt(m)).Int64()
+				if got := BinomialMod(n, k, m); got != want {
+					t.Errorf("BinomialMod(%d, %d, %d) == %d, want %d", n, k, m, got, want)
+				}
+			}
+		}
+	}
+	if got := BinomialMod(1000, 500, 1000000000); got != 821216320 {
+		t.Errorf("BinomialMod(1000, 500, 10^9) == %d, want %d", got, 821216320)
+	}
+	if got := BinomialMod(123456789, 54321, 1000000000); got != 870000000 {
+		t.Errorf("BinomialMod(123456789, 54321, 10^9) == %d, want %d", got, 870000000)
+	}
+	if got := BinomialMod(5, 6, 10); got != 0 {
+		t.Errorf("BinomialMod(5, 6, 10) == %d, want 0", got)
+	}
+}
+
+func TestBinomialModTable(t *testing.T) {
+	// 5^9 has about 2 million units, which are only tabulated once for all queries
+	moduli := []int64{1, 12, 1953125, 1000000000, 2 * 1000003}
+	for _, m := range moduli {
+		table := NewBinomialModTable(m)
+		if table.Mod() != m {
+			t.Errorf("Mod() == %d, want %d", table.Mod(), m)
+		}
+		for n := int64(0); n <= 120; n += 11 {
+			for k := int64(-1); k <= n+1; k += 5 {
+				want := int64(0)
+				if k >= 0 && k <= n {
+					want = new(big.Int).Mod(Binomial(n, k), big.NewInt(m)).Int64()
+				}
+				if got := table.Binomial(n, k); got != want {
+					t.Errorf("Binomial(%d, %d) mod %d == %d, want %d", n, k, m, got, want)
+				}
+			}
+		}
+		for _, nk := range [][2]int64{{123456789, 54321}, {987654321987, 123456789}} {
+			if got, want := table.Binomial(nk[0], nk[1]), BinomialMod(nk[0], nk[1], m); got != want {
+				t.Errorf("Binomial(%d, %d) mod %d == %d, want %d", nk[0], nk[1], m, got, want)
+			}
+		}
+	}
+}
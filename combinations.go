@@ -0,0 +1,156 @@
+package eulerlib
+
+import (
+	"iter"
+	"math/big"
+)
+
+// KCombinations returns an iterator over all k-element combinations of set, in lexicographic
+// order of the element positions. Each step does O(k) work, the yielded slices are new copies
+// and set is not modified. Repeated elements are treated as distinct.
+//
+// Example:
+// for c := range KCombinations([]int{1, 2, 3, 4}, 2) { ... }
+// // yields [1 2], [1 3], [1 4], [2 3], [2 4], [3 4]
+func KCombinations[E any](set []E, k int) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		n := len(set)
+		if k < 0 || k > n {
+			return
+		}
+		idx := Range(0, k)
+		for {
+			comb := make([]E, k)
+			for i, j := range idx {
+				comb[i] = set[j]
+			}
+			if !yield(comb) || !nextCombination(idx, n) {
+				return
+			}
+		}
+	}
+}
+
+// CombinationsWithRepetition returns an iterator over all k-element multisets drawn from set,
+// in lexicographic order of the element positions. There are C(len(set)+k-1, k) of them.
+//
+// Example:
+// for c := range CombinationsWithRepetition([]int{1, 2, 3}, 2) { ... }
+// // yields [1 1], [1 2], [1 3], [2 2], [2 3], [3 3]
+func CombinationsWithRepetition[E any](set []E, k int) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		n := len(set)
+		if k < 0 || (n == 0 && k > 0) {
+			return
+		}
+		idx := make([]int, k)
+		for {
+			comb := make([]E, k)
+			for i, j := range idx {
+				comb[i] = set[j]
+			}
+			if !yield(comb) {
+				return
+			}
+			// increment the rightmost index that is not at the last element and copy it to its right
+			i := k - 1
+			for i >= 0 && idx[i] == n-1 {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			idx[i]++
+			for j := i + 1; j < k; j++ {
+				idx[j] = idx[i]
+			}
+		}
+	}
+}
+
+// CombinationRank returns the 0-based rank of the combination comb of {0, ..., n-1} in
+// the lexicographic order used by KCombinations. comb must be strictly increasing.
+// The rank is C(n, k) - 1 - Σ C(n-1-comb[i], k-i), counting the combinations after comb.
+func CombinationRank(comb []int, n int) *big.Int {
+	k := len(comb)
+	for i, c := range comb {
+		if c < 0 || c >= n || (i > 0 && c <= comb[i-1]) {
+			panic("comb must be strictly increasing indices in [0, n)")
+		}
+	}
+	res := Binomial(n, k)
+	res.Sub(res, big.NewInt(1))
+	for i, c := range comb {
+		res.Sub(res, Binomial(n-1-c, k-i))
+	}
+	return res
+}
+
+// CombinationUnrank returns the k-combination of {0, ..., n-1} with the given 0-based rank
+// in lexicographic order, the inverse of CombinationRank.
+// It panics if rank is not in [0, C(n, k)).
+//
+// Example:
+// c := CombinationUnrank(big.NewInt(4), 4, 2)
+// // c == [1 3]
+func CombinationUnrank(rank *big.Int, n, k int) []int {
+	if k < 0 || k > n {
+		panic("k must be in [0, n]")
+	}
+	total := Binomial(n, k)
+	if rank.Sign() < 0 || rank.Cmp(total) >= 0 {
+		panic("rank out of range")
+	}
+	// greedily peel off the largest binomials of the complementary rank
+	r := total.Sub(total, big.NewInt(1))
+	r.Sub(r, rank)
+	res := make([]int, k)
+	m := n - 1
+	for i := range k {
+		for Binomial(m, k-i).Cmp(r) > 0 {
+			m--
+		}
+		r.Sub(r, Binomial(m, k-i))
+		res[i] = n - 1 - m
+		m--
+	}
+	return res
+}
+
+// nextCombination advances the strictly increasing indices idx into {0, ..., n-1} to the
+// next combination in lexicographic order and reports false when idx was the last one
+func nextCombination(idx []int, n int) bool {
+	k := len(idx)
+	i := k - 1
+	for i >= 0 && idx[i] == n-k+i {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	idx[i]++
+	for j := i + 1; j < k; j++ {
+		idx[j] = idx[j-1] + 1
+	}
+	return true
+}
+
+// nextColexCombination advances idx to the next combination in colexicographic order,
+// which is the order of the bitmasks with len(idx) bits set, and reports false at the end
+func nextColexCombination(idx []int, n int) bool {
+	k := len(idx)
+	for i := range k {
+		limit := n
+		if i+1 < k {
+			limit = idx[i+1]
+		}
+		if idx[i]+1 < limit {
+			idx[i]++
+			for j := range i {
+				idx[j] = j
+			}
+			return true
+		}
+	}
+	return false
+}
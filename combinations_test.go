@@ -0,0 +1,114 @@
+package eulerlib
+
+import (
+	"math/big"
+	"math/bits"
+	"reflect"
+	"testing"
+)
+
+func TestKCombinations(t *testing.T) {
+	got := [][]int{}
+	for c := range KCombinations([]int{1, 2, 3, 4}, 2) {
+		got = append(got, c)
+	}
+	want := [][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("KCombinations(1..4, 2) == %v, want %v", got, want)
+	}
+
+	// 3 of 100 elements is far beyond any bitmask
+	count := 0
+	for range KCombinations(Range(0, 100), 3) {
+		count++
+	}
+	if count != 161700 {
+		t.Errorf("KCombinations(100, 3) yielded %d, want %d", count, 161700)
+	}
+
+	for _, k := range []int{-1, 5} {
+		for c := range KCombinations([]int{1, 2, 3, 4}, k) {
+			t.Errorf("KCombinations(1..4, %d) yielded %v, want nothing", k, c)
+		}
+	}
+	count = 0
+	for c := range KCombinations([]int{1, 2}, 0) {
+		if len(c) != 0 {
+			t.Errorf("KCombinations(1..2, 0) yielded %v, want []", c)
+		}
+		count++
+	}
+	if count != 1 {
+		t.Errorf("KCombinations(1..2, 0) yielded %d combinations, want 1", count)
+	}
+}
+
+func TestCombinationsWithRepetition(t *testing.T) {
+	got := [][]string{}
+	for c := range CombinationsWithRepetition([]string{"a", "b", "c"}, 2) {
+		got = append(got, c)
+	}
+	want := [][]string{{"a", "a"}, {"a", "b"}, {"a", "c"}, {"b", "b"}, {"b", "c"}, {"c", "c"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CombinationsWithRepetition(abc, 2) == %v, want %v", got, want)
+	}
+
+	count := 0
+	for range CombinationsWithRepetition(Range(0, 10), 4) {
+		count++
+	}
+	if want := Binomial(13, 4).Int64(); int64(count) != want {
+		t.Errorf("CombinationsWithRepetition(10, 4) yielded %d, want %d", count, want)
+	}
+}
+
+func TestCombinationRank(t *testing.T) {
+	n, k := 8, 3
+	rank := int64(0)
+	for c := range KCombinations(Range(0, n), k) {
+		if got := CombinationRank(c, n); got.Int64() != rank {
+			t.Fatalf("CombinationRank(%v, %d) == %s, want %d", c, n, got, rank)
+		}
+		if got := CombinationUnrank(big.NewInt(rank), n, k); !reflect.DeepEqual(got, c) {
+			t.Fatalf("CombinationUnrank(%d, %d, %d) == %v, want %v", rank, n, k, got, c)
+		}
+		rank++
+	}
+
+	// the last 50-combination of 100 elements
+	last := Range(50, 100)
+	want := new(big.Int).Sub(Binomial(100, 50), big.NewInt(1))
+	if got := CombinationRank(last, 100); got.Cmp(want) != 0 {
+		t.Errorf("CombinationRank(50..99, 100) == %s, want %s", got, want)
+	}
+	if got := CombinationUnrank(want, 100, 50); !reflect.DeepEqual(got, last) {
+		t.Errorf("CombinationUnrank(C(100, 50) - 1, 100, 50) == %v, want %v", got, last)
+	}
+}
+
+func TestCombinationsFixedLength(t *testing.T) {
+	// fixed lengths keep the bitmask order
+	set := []int{1, 2, 3, 4, 5}
+	for k := 1; k <= len(set); k++ {
+		want := [][]int{}
+		for mask := 1; mask < 1<<len(set); mask++ {
+			if bits.OnesCount(uint(mask)) != k {
+				continue
+			}
+			subset := []int{}
+			for i := range set {
+				if mask>>i&1 == 1 {
+					subset = append(subset, set[i])
+				}
+			}
+			want = append(want, subset)
+		}
+		if got := Combinations(set, k); !reflect.DeepEqual(got, want) {
+			t.Errorf("Combinations(%v, %d) == %v, want %v", set, k, got, want)
+		}
+	}
+
+	if got := len(Combinations(Range(0, 70), 2)); got != 2415 {
+		t.Errorf("len(Combinations(70 elements, 2)) == %d, want %d", got, 2415)
+	}
+}
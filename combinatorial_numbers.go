@@ -0,0 +1,392 @@
+package eulerlib
+
+import "math/big"
+
+// The modular functions in this file accept any modulus m >= 1 below 2^63 and avoid division,
+// so they use recurrences that can be slower than the exact ones, which are noted per function.
+// List functions return the values for 0..n, table functions the rows 0..n of a triangle
+// whose row i holds k = 0..i.
+
+// CatalanNumber returns the nth Catalan number C(2n, n)/(n+1), the number of balanced
+// strings of n pairs of parentheses
+//
+// Example:
+// c := CatalanNumber(10)
+// // c == 16796
+func CatalanNumber(n int) *big.Int {
+	if n < 0 {
+		panic("n must not be negative")
+	}
+	res := Binomial(2*n, n)
+	return res.Quo(res, big.NewInt(int64(n+1)))
+}
+
+// CatalanNumbers returns the Catalan numbers C(0), ..., C(n), using (n+2)·C(n+1) = (4n+2)·C(n)
+func CatalanNumbers(n int) []*big.Int {
+	if n < 0 {
+		return nil
+	}
+	res := make([]*big.Int, n+1)
+	res[0] = big.NewInt(1)
+	for i := range n {
+		res[i+1] = new(big.Int).Mul(res[i], big.NewInt(int64(4*i+2)))
+		res[i+1].Quo(res[i+1], big.NewInt(int64(i+2)))
+	}
+	return res
+}
+
+// CatalanNumbersMod returns C(0), ..., C(n) mod m, using the convolution
+// C(n+1) = Σ C(i)·C(n-i) in O(n²) time
+func CatalanNumbersMod(n int, m int64) []int64 {
+	return sequenceMod(n, m, func(c []int64, i int) int64 {
+		if i == 0 {
+			return 1 % m
+		}
+		return convolveMod(c[:i], c[:i], m)
+	})
+}
+
+// CatalanNumberMod returns C(n) mod m in O(n²) time
+func CatalanNumberMod(n int, m int64) int64 {
+	return lastMod(CatalanNumbersMod(n, m))
+}
+
+// MotzkinNumbers returns the Motzkin numbers M(0), ..., M(n), the number of ways to draw
+// non-crossing chords between n points on a circle, using
+// (n+2)·M(n) = (2n+1)·M(n-1) + 3(n-1)·M(n-2)
+func MotzkinNumbers(n int) []*big.Int {
+	if n < 0 {
+		return nil
+	}
+	res := make([]*big.Int, n+1)
+	for i := range res {
+		if i < 2 {
+			res[i] = big.NewInt(1)
+			continue
+		}
+		res[i] = new(big.Int).Mul(res[i-1], big.NewInt(int64(2*i+1)))
+		res[i].Add(res[i], new(big.Int).Mul(res[i-2], big.NewInt(int64(3*i-3))))
+		res[i].Quo(res[i], big.NewInt(int64(i+2)))
+	}
+	return res
+}
+
+// MotzkinNumber returns the nth Motzkin number
+//
+// Example:
+// m := MotzkinNumber(10)
+// // m == 2188
+func MotzkinNumber(n int) *big.Int {
+	if n < 0 {
+		panic("n must not be negative")
+	}
+	return MotzkinNumbers(n)[n]
+}
+
+// MotzkinNumbersMod returns M(0), ..., M(n) mod m, using
+// M(n) = M(n-1) + Σ M(i)·M(n-2-i) in O(n²) time
+func MotzkinNumbersMod(n int, m int64) []int64 {
+	return sequenceMod(n, m, func(c []int64, i int) int64 {
+		if i < 2 {
+			return 1 % m
+		}
+		return addMod(c[i-1], convolveMod(c[:i-1], c[:i-1], m), m)
+	})
+}
+
+// MotzkinNumberMod returns M(n) mod m in O(n²) time
+func MotzkinNumberMod(n int, m int64) int64 {
+	return lastMod(MotzkinNumbersMod(n, m))
+}
+
+// SchroderNumbers returns the large Schröder numbers S(0), ..., S(n), the number of lattice
+// paths from (0, 0) to (n, n) with steps (1, 0), (0, 1) and (1, 1) that never rise above
+// the diagonal, using (n+1)·S(n) = 3(2n-1)·S(n-1) - (n-2)·S(n-2)
+func SchroderNumbers(n int) []*big.Int {
+	if n < 0 {
+		return nil
+	}
+	res := make([]*big.Int, n+1)
+	for i := range res {
+		if i < 2 {
+			res[i] = big.NewInt(int64(i + 1))
+			continue
+		}
+		res[i] = new(big.Int).Mul(res[i-1], big.NewInt(int64(6*i-3)))
+		res[i].Sub(res[i], new(big.Int).Mul(res[i-2], big.NewInt(int64(i-2))))
+		res[i].Quo(res[i], big.NewInt(int64(i+1)))
+	}
+	return res
+}
+
+// SchroderNumber returns the nth large Schröder number
+//
+// Example:
+// s := SchroderNumber(5)
+// // s == 394
+func SchroderNumber(n int) *big.Int {
+	if n < 0 {
+		panic("n must not be negative")
+	}
+	return SchroderNumbers(n)[n]
+}
+
+// SchroderNumbersMod returns S(0), ..., S(n) mod m, using
+// S(n) = S(n-1) + Σ S(i)·S(n-1-i) in O(n²) time
+func SchroderNumbersMod(n int, m int64) []int64 {
+	return sequenceMod(n, m, func(c []int64, i int) int64 {
+		if i == 0 {
+			return 1 % m
+		}
+		return addMod(c[i-1], convolveMod(c[:i], c[:i], m), m)
+	})
+}
+
+// SchroderNumberMod returns S(n) mod m in O(n²) time
+func SchroderNumberMod(n int, m int64) int64 {
+	return lastMod(SchroderNumbersMod(n, m))
+}
+
+// DerangementCounts returns D(0), ..., D(n), the number of permutations without fixed points,
+// using D(n) = (n-1)·(D(n-1) + D(n-2))
+func DerangementCounts(n int) []*big.Int {
+	if n < 0 {
+		return nil
+	}
+	res := make([]*big.Int, n+1)
+	for i := range res {
+		if i < 2 {
+			res[i] = big.NewInt(int64(1 - i))
+			continue
+		}
+		res[i] = new(big.Int).Add(res[i-1], res[i-2])
+		res[i].Mul(res[i], big.NewInt(int64(i-1)))
+	}
+	return res
+}
+
+// DerangementCount returns D(n), the number of permutations of n elements without fixed points
+//
+// Example:
+// d := DerangementCount(5)
+// // d == 44
+func DerangementCount(n int) *big.Int {
+	if n < 0 {
+		panic("n must not be negative")
+	}
+	return DerangementCounts(n)[n]
+}
+
+// DerangementCountsMod returns D(0), ..., D(n) mod m in O(n) time
+func DerangementCountsMod(n int, m int64) []int64 {
+	return sequenceMod(n, m, func(c []int64, i int) int64 {
+		if i < 2 {
+			return int64(1-i) % m
+		}
+		return mulMod(addMod(c[i-1], c[i-2], m), int64(i-1)%m, m)
+	})
+}
+
+// DerangementCountMod returns D(n) mod m in O(n) time
+func DerangementCountMod(n int, m int64) int64 {
+	return lastMod(DerangementCountsMod(n, m))
+}
+
+// lahCoef is the recurrence L(n+1, k) = (n+k)·L(n, k) + L(n, k-1) of the Lah numbers
+func lahCoef(i, k int) (int64, int64) {
+	return int64(i + k), 1
+}
+
+// LahTable returns the rows 0..n of the unsigned Lah numbers L(n, k), the number of ways to
+// partition an n-set into k non-empty ordered lists
+func LahTable(n int) [][]*big.Int {
+	return triangle(n, lahCoef)
+}
+
+// LahNumber returns the unsigned Lah number L(n, k) = C(n-1, k-1)·n!/k!,
+// and 0 if k is not in [0, n]
+//
+// Example:
+// l := LahNumber(5, 2)
+// // l == 240
+func LahNumber(n, k int) *big.Int {
+	if k < 0 || k > n {
+		return new(big.Int)
+	}
+	if n == 0 || k == 0 {
+		// L(0, 0) = 1 and L(n, 0) = 0 otherwise
+		if n == 0 {
+			return big.NewInt(1)
+		}
+		return new(big.Int)
+	}
+	res := Binomial(n-1, k-1)
+	res.Mul(res, FactorialBigInt(int64(n)))
+	return res.Quo(res, FactorialBigInt(int64(k)))
+}
+
+// LahTableMod returns the rows 0..n of the Lah numbers modulo m
+func LahTableMod(n int, m int64) [][]int64 {
+	return triangleMod(n, m, lahCoef)
+}
+
+// LahNumberMod returns L(n, k) mod m in O(n·k) time
+func LahNumberMod(n, k int, m int64) int64 {
+	return triangleEntryMod(n, k, m, lahCoef)
+}
+
+// eulerianCoef is the recurrence A(n+1, k) = (k+1)·A(n, k) + (n+1-k)·A(n, k-1) of the Eulerian numbers
+func eulerianCoef(i, k int) (int64, int64) {
+	return int64(k + 1), int64(i + 1 - k)
+}
+
+// EulerianTable returns the rows 0..n of the Eulerian numbers A(n, k), the number of
+// permutations of n elements with exactly k ascents. A(n, n) is 0 for n >= 1.
+func EulerianTable(n int) [][]*big.Int {
+	return triangle(n, eulerianCoef)
+}
+
+// EulerianNumber returns A(n, k) = Σ (-1)^j·C(n+1, j)·(k+1-j)^n for j = 0..k,
+// and 0 if k is not in [0, n]
+//
+// Example:
+// a := EulerianNumber(5, 2)
+// // a == 66
+func EulerianNumber(n, k int) *big.Int {
+	if k < 0 || k > n {
+		return new(big.Int)
+	}
+	if n == 0 {
+		return big.NewInt(1)
+	}
+	res, term := new(big.Int), new(big.Int)
+	for j := 0; j <= k; j++ {
+		term.Exp(big.NewInt(int64(k+1-j)), big.NewInt(int64(n)), nil)
+		term.Mul(term, Binomial(n+1, j))
+		if j%2 == 0 {
+			res.Add(res, term)
+		} else {
+			res.Sub(res, term)
+		}
+	}
+	return res
+}
+
+// EulerianTableMod returns the rows 0..n of the Eulerian numbers modulo m
+func EulerianTableMod(n int, m int64) [][]int64 {
+	return triangleMod(n, m, eulerianCoef)
+}
+
+// EulerianNumberMod returns A(n, k) mod m in O(n·k) time
+func EulerianNumberMod(n, k int, m int64) int64 {
+	return triangleEntryMod(n, k, m, eulerianCoef)
+}
+
+// NarayanaTable returns the rows 0..n of the Narayana numbers N(n, k), the number of Dyck
+// paths of length 2n with k peaks. N(0, 0) is 1 and N(n, 0) is 0 otherwise.
+func NarayanaTable(n int) [][]*big.Int {
+	if n < 0 {
+		return nil
+	}
+	t := make([][]*big.Int, n+1)
+	for i := range t {
+		t[i] = make([]*big.Int, i+1)
+		for k := range t[i] {
+			t[i][k] = NarayanaNumber(i, k)
+		}
+	}
+	return t
+}
+
+// NarayanaNumber returns N(n, k) = C(n, k)·C(n, k-1)/n, and 0 if k is not in [0, n]
+//
+// Example:
+// c := NarayanaNumber(5, 2)
+// // c == 20
+func NarayanaNumber(n, k int) *big.Int {
+	if k < 0 || k > n {
+		return new(big.Int)
+	}
+	if n == 0 {
+		return big.NewInt(1)
+	}
+	if k == 0 {
+		return new(big.Int)
+	}
+	res := Binomial(n, k)
+	res.Mul(res, Binomial(n, k-1))
+	return res.Quo(res, big.NewInt(int64(n)))
+}
+
+// NarayanaTableMod returns the rows 0..n of the Narayana numbers modulo m, using
+// N(n, k) = C(n-1, k-1)² - C(n-1, k-2)·C(n-1, k) on the rows of Pascal's triangle in O(n²) time
+func NarayanaTableMod(n int, m int64) [][]int64 {
+	if m < 1 {
+		panic("modulus must be positive")
+	}
+	if n < 0 {
+		return nil
+	}
+	t := make([][]int64, n+1)
+	t[0] = []int64{1 % m}
+	pascal := []int64{1 % m}
+	for i := 1; i <= n; i++ {
+		// pascal holds row i-1 of Pascal's triangle
+		at := func(k int) int64 {
+			if k < 0 || k >= len(pascal) {
+				return 0
+			}
+			return pascal[k]
+		}
+		t[i] = make([]int64, i+1)
+		for k := 1; k <= i; k++ {
+			t[i][k] = normMod(mulMod(at(k-1), at(k-1), m)-mulMod(at(k-2), at(k), m), m)
+		}
+		next := make([]int64, i+1)
+		for k := range next {
+			next[k] = addMod(at(k), at(k-1), m)
+		}
+		pascal = next
+	}
+	return t
+}
+
+// NarayanaNumberMod returns N(n, k) mod m in O(n²) time
+func NarayanaNumberMod(n, k int, m int64) int64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	return NarayanaTableMod(n, m)[n][k]
+}
+
+// sequenceMod returns c[0..n] modulo m where c[i] = next(c, i)
+func sequenceMod(n int, m int64, next func(c []int64, i int) int64) []int64 {
+	if m < 1 {
+		panic("modulus must be positive")
+	}
+	if n < 0 {
+		return nil
+	}
+	c := make([]int64, n+1)
+	for i := range c {
+		c[i] = next(c, i)
+	}
+	return c
+}
+
+// convolveMod returns Σ a[j]·b[len(b)-1-j] mod m for slices of equal length
+func convolveMod(a, b []int64, m int64) int64 {
+	var sum int64
+	for j := range a {
+		sum = addMod(sum, mulMod(a[j], b[len(b)-1-j], m), m)
+	}
+	return sum
+}
+
+// lastMod returns the last element of a sequence computed for 0..n and panics for negative n
+func lastMod(c []int64) int64 {
+	if len(c) == 0 {
+		panic("n must not be negative")
+	}
+	return c[len(c)-1]
+}
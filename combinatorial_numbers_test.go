@@ -0,0 +1,104 @@
+package eulerlib
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestCombinatorialSequences(t *testing.T) {
+	const m = 1000000000 // composite on purpose, the modular functions must not divide
+	tests := []struct {
+		name   string
+		list   func(int) []*big.Int
+		single func(int) *big.Int
+		listM  func(int, int64) []int64
+		modM   func(int, int64) int64
+		want   []int64 // OEIS
+	}{
+		{"Catalan", CatalanNumbers, CatalanNumber, CatalanNumbersMod, CatalanNumberMod,
+			[]int64{1, 1, 2, 5, 14, 42, 132, 429, 1430, 4862, 16796, 58786}},
+		{"Motzkin", MotzkinNumbers, MotzkinNumber, MotzkinNumbersMod, MotzkinNumberMod,
+			[]int64{1, 1, 2, 4, 9, 21, 51, 127, 323, 835, 2188, 5798}},
+		{"Schroder", SchroderNumbers, SchroderNumber, SchroderNumbersMod, SchroderNumberMod,
+			[]int64{1, 2, 6, 22, 90, 394, 1806, 8558, 41586, 206098}},
+		{"Derangement", DerangementCounts, DerangementCount, DerangementCountsMod, DerangementCountMod,
+			[]int64{1, 0, 1, 2, 9, 44, 265, 1854, 14833, 133496}},
+	}
+	for _, tt := range tests {
+		for n, v := range tt.list(len(tt.want) - 1) {
+			if v.Int64() != tt.want[n] {
+				t.Errorf("%s list [%d] == %s, want %d", tt.name, n, v, tt.want[n])
+			}
+		}
+		const n = 80
+		exact, mod := tt.list(n), tt.listM(n, m)
+		for i := range exact {
+			want := new(big.Int).Mod(exact[i], big.NewInt(m)).Int64()
+			if mod[i] != want {
+				t.Errorf("%s mod list [%d] == %d, want %d", tt.name, i, mod[i], want)
+			}
+		}
+		if got := tt.single(n); got.Cmp(exact[n]) != 0 {
+			t.Errorf("%s(%d) == %s, want %s", tt.name, n, got, exact[n])
+		}
+		if got := tt.modM(n, m); got != mod[n] {
+			t.Errorf("%s mod(%d) == %d, want %d", tt.name, n, got, mod[n])
+		}
+	}
+}
+
+func TestCombinatorialTriangles(t *testing.T) {
+	const m = 1000000000
+	tests := []struct {
+		name   string
+		table  func(int) [][]*big.Int
+		single func(int, int) *big.Int
+		tableM func(int, int64) [][]int64
+		modM   func(int, int, int64) int64
+		want   [][]int64 // OEIS
+	}{
+		{"Lah", LahTable, LahNumber, LahTableMod, LahNumberMod,
+			[][]int64{{1}, {0, 1}, {0, 2, 1}, {0, 6, 6, 1}, {0, 24, 36, 12, 1}, {0, 120, 240, 120, 20, 1}}},
+		{"Eulerian", EulerianTable, EulerianNumber, EulerianTableMod, EulerianNumberMod,
+			[][]int64{{1}, {1, 0}, {1, 1, 0}, {1, 4, 1, 0}, {1, 11, 11, 1, 0}, {1, 26, 66, 26, 1, 0}}},
+		{"Narayana", NarayanaTable, NarayanaNumber, NarayanaTableMod, NarayanaNumberMod,
+			[][]int64{{1}, {0, 1}, {0, 1, 1}, {0, 1, 3, 1}, {0, 1, 6, 6, 1}, {0, 1, 10, 20, 10, 1}}},
+	}
+	for _, tt := range tests {
+		table := tt.table(len(tt.want) - 1)
+		for n := range tt.want {
+			for k := range tt.want[n] {
+				if table[n][k].Int64() != tt.want[n][k] {
+					t.Errorf("%s table [%d][%d] == %s, want %d", tt.name, n, k, table[n][k], tt.want[n][k])
+				}
+			}
+		}
+		const n = 40
+		exact, mod := tt.table(n), tt.tableM(n, m)
+		for i := range exact {
+			for k := -1; k <= i+1; k++ {
+				want := new(big.Int)
+				if k >= 0 && k <= i {
+					want = exact[i][k]
+					if got := new(big.Int).Mod(want, big.NewInt(m)).Int64(); mod[i][k] != got {
+						t.Errorf("%s mod table [%d][%d] == %d, want %d", tt.name, i, k, mod[i][k], got)
+					}
+				}
+				if got := tt.single(i, k); got.Cmp(want) != 0 {
+					t.Errorf("%s(%d, %d) == %s, want %s", tt.name, i, k, got, want)
+				}
+				if got, w := tt.modM(i, k, m), new(big.Int).Mod(want, big.NewInt(m)).Int64(); got != w {
+					t.Errorf("%s mod(%d, %d) == %d, want %d", tt.name, i, k, got, w)
+				}
+			}
+		}
+	}
+	// every row of the Eulerian numbers sums to n!
+	sum := new(big.Int)
+	for _, a := range EulerianTable(12)[12] {
+		sum.Add(sum, a)
+	}
+	if sum.Cmp(FactorialBigInt(12)) != 0 {
+		t.Errorf("sum of EulerianTable(12)[12] == %s, want 12!", sum)
+	}
+}
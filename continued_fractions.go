@@ -0,0 +1,155 @@
+package eulerlib
+
+import (
+	"iter"
+	"math/big"
+)
+
+// ContinuedFractionSqrt returns the continued fraction of sqrt(n) as its integer part a0
+// and the repeating period, so that sqrt(n) = [a0; (period...)].
+// The expansion uses exact integer arithmetic; the period is empty if n is a perfect square.
+//
+// Example:
+// a0, period := ContinuedFractionSqrt(23)
+// // a0 == 4, period == [1 3 1 8]
+func ContinuedFractionSqrt(n int64) (a0 int64, period []int64) {
+	if n < 0 {
+		panic("n must be positive")
+	}
+	a0 = isqrt(n)
+	if a0*a0 == n {
+		return a0, []int64{}
+	}
+	// sqrt(n) = a0 + (sqrt(n) - a0), every complete quotient is (sqrt(n) + m) / d
+	m, d, a := int64(0), int64(1), a0
+	for a != 2*a0 {
+		m = d*a - m
+		d = (n - m*m) / d
+		a = (a0 + m) / d
+		period = append(period, a)
+	}
+	return
+}
+
+// ContinuedFractionRat returns the finite continued fraction [a0; a1, ..., ak] of x.
+// a0 is the floor of x and every later term is positive; the last term is > 1 unless x is an integer.
+func ContinuedFractionRat(x *big.Rat) (res []*big.Int) {
+	num, den := new(big.Int).Set(x.Num()), new(big.Int).Set(x.Denom())
+	for den.Sign() != 0 {
+		// DivMod floors for positive den, which keeps the remainder non-negative
+		a, rem := new(big.Int).DivMod(num, den, new(big.Int))
+		res = append(res, a)
+		num, den = den, rem
+	}
+	return
+}
+
+// ContinuedFractionFloat returns the continued fraction of the exact binary value of x.
+// Since x is rounded, only the leading terms agree with the real number x approximates.
+func ContinuedFractionFloat(x float64) []*big.Int {
+	return ContinuedFractionRat(new(big.Rat).SetFloat64(x))
+}
+
+// ContinuedFractionE returns an infinite iterator over the terms of e = [2; 1, 2, 1, 1, 4, 1, 1, 6, 1, ...]
+func ContinuedFractionE() iter.Seq[int64] {
+	return func(yield func(int64) bool) {
+		if !yield(2) {
+			return
+		}
+		for k := int64(2); ; k += 2 {
+			if !yield(1) || !yield(k) || !yield(1) {
+				return
+			}
+		}
+	}
+}
+
+// PeriodicTerms returns an infinite iterator over the terms [a0; period, period, ...],
+// as returned by ContinuedFractionSqrt. If the period is empty only a0 is yielded.
+func PeriodicTerms(a0 int64, period []int64) iter.Seq[int64] {
+	return func(yield func(int64) bool) {
+		if !yield(a0) || len(period) == 0 {
+			return
+		}
+		for {
+			for _, a := range period {
+				if !yield(a) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Convergents returns an iterator over the convergents h/k of the continued fraction with
+// the given terms, using h(n) = a(n)·h(n-1) + h(n-2) and k(n) = a(n)·k(n-1) + k(n-2).
+// The yielded Big Integers are fresh for every step.
+//
+// Example:
+// for h, k := range Convergents(PeriodicTerms(ContinuedFractionSqrt(2))) { ... }
+// // yields 1/1, 3/2, 7/5, 17/12, ...
+func Convergents[E Integer](terms iter.Seq[E]) iter.Seq2[*big.Int, *big.Int] {
+	return ConvergentsBig(func(yield func(*big.Int) bool) {
+		for a := range terms {
+			if !yield(integerToBig(a)) {
+				return
+			}
+		}
+	})
+}
+
+// ConvergentsBig is Convergents for terms that are Big Integers, such as those of ContinuedFractionRat
+func ConvergentsBig(terms iter.Seq[*big.Int]) iter.Seq2[*big.Int, *big.Int] {
+	return func(yield func(*big.Int, *big.Int) bool) {
+		h0, h1 := big.NewInt(0), big.NewInt(1)
+		k0, k1 := big.NewInt(1), big.NewInt(0)
+		for a := range terms {
+			h0, h1 = h1, new(big.Int).Add(new(big.Int).Mul(a, h1), h0)
+			k0, k1 = k1, new(big.Int).Add(new(big.Int).Mul(a, k1), k0)
+			if !yield(new(big.Int).Set(h1), new(big.Int).Set(k1)) {
+				return
+			}
+		}
+	}
+}
+
+// BestApproximation returns the fraction p/q closest to x with 1 <= q <= maxDenominator.
+// The candidates are the last convergent of x within the bound and the largest
+// semiconvergent after it; on a tie the convergent, which has the smaller denominator, wins.
+//
+// Example:
+// r := BestApproximation(new(big.Rat).SetFloat64(math.Pi), 1000)
+// // r == 355/113
+func BestApproximation(x *big.Rat, maxDenominator int64) Rational[int64] {
+	if maxDenominator < 1 {
+		panic("maxDenominator must be positive")
+	}
+	maxDen := big.NewInt(maxDenominator)
+	if x.Denom().Cmp(maxDen) <= 0 {
+		return RationalFromBig[int64](x)
+	}
+	p0, q0, p1, q1 := big.NewInt(0), big.NewInt(1), big.NewInt(1), big.NewInt(0)
+	num, den := new(big.Int).Set(x.Num()), new(big.Int).Set(x.Denom())
+	for {
+		a, rem := new(big.Int).DivMod(num, den, new(big.Int))
+		q2 := new(big.Int).Add(q0, new(big.Int).Mul(a, q1))
+		if q2.Cmp(maxDen) > 0 {
+			break
+		}
+		p0, q0, p1, q1 = p1, q1, new(big.Int).Add(p0, new(big.Int).Mul(a, p1)), q2
+		num, den = den, rem
+	}
+	k := new(big.Int).Sub(maxDen, q0)
+	k.Div(k, q1)
+	semi := new(big.Rat).SetFrac(
+		new(big.Int).Add(p0, new(big.Int).Mul(k, p1)),
+		new(big.Int).Add(q0, new(big.Int).Mul(k, q1)),
+	)
+	conv := new(big.Rat).SetFrac(p1, q1)
+	distSemi := new(big.Rat).Sub(semi, x)
+	distConv := new(big.Rat).Sub(conv, x)
+	if distConv.Abs(distConv).Cmp(distSemi.Abs(distSemi)) <= 0 {
+		return RationalFromBig[int64](conv)
+	}
+	return RationalFromBig[int64](semi)
+}
@@ -0,0 +1,144 @@
+package eulerlib
+
+import (
+	"iter"
+	"math"
+	"math/big"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestContinuedFractionSqrt(t *testing.T) {
+	testCases := []struct {
+		n      int64
+		a0     int64
+		period []int64
+	}{
+		{2, 1, []int64{2}},
+		{13, 3, []int64{1, 1, 1, 1, 6}},
+		{23, 4, []int64{1, 3, 1, 8}},
+		{16, 4, []int64{}},
+		{0, 0, []int64{}},
+	}
+	for _, tc := range testCases {
+		a0, period := ContinuedFractionSqrt(tc.n)
+		if a0 != tc.a0 || !reflect.DeepEqual(period, tc.period) {
+			t.Errorf("ContinuedFractionSqrt(%d) = %d, %v, want %d, %v", tc.n, a0, period, tc.a0, tc.period)
+		}
+	}
+	// Project Euler 64: 1322 of the square roots up to 10000 have an odd period
+	odd := 0
+	for n := int64(2); n <= 10000; n++ {
+		if _, period := ContinuedFractionSqrt(n); len(period)%2 == 1 {
+			odd++
+		}
+	}
+	if odd != 1322 {
+		t.Errorf("%d continued fractions of sqrt(n <= 10000) have an odd period, want 1322", odd)
+	}
+}
+
+func TestContinuedFractionRat(t *testing.T) {
+	testCases := []struct {
+		x    *big.Rat
+		want []int64
+	}{
+		{big.NewRat(415, 93), []int64{4, 2, 6, 7}},
+		{big.NewRat(-415, 93), []int64{-5, 1, 1, 6, 7}},
+		{big.NewRat(3, 1), []int64{3}},
+		{big.NewRat(1, 3), []int64{0, 3}},
+	}
+	for _, tc := range testCases {
+		got := ContinuedFractionRat(tc.x)
+		if len(got) != len(tc.want) {
+			t.Errorf("ContinuedFractionRat(%s) = %v, want %v", tc.x, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i].Int64() != tc.want[i] {
+				t.Errorf("ContinuedFractionRat(%s) = %v, want %v", tc.x, got, tc.want)
+				break
+			}
+		}
+		// the last convergent is the number itself
+		var h, k *big.Int
+		for h, k = range ConvergentsBig(slices.Values(got)) {
+		}
+		if new(big.Rat).SetFrac(h, k).Cmp(tc.x) != 0 {
+			t.Errorf("last convergent of %s is %s/%s", tc.x, h, k)
+		}
+	}
+	pi := ContinuedFractionFloat(math.Pi)
+	for i, want := range []int64{3, 7, 15, 1, 292, 1, 1, 1, 2} {
+		if pi[i].Int64() != want {
+			t.Errorf("ContinuedFractionFloat(π)[%d] = %s, want %d", i, pi[i], want)
+		}
+	}
+}
+
+func TestConvergents(t *testing.T) {
+	got := [][2]int64{}
+	for h, k := range Convergents(PeriodicTerms(ContinuedFractionSqrt(2))) {
+		got = append(got, [2]int64{h.Int64(), k.Int64()})
+		if len(got) == 6 {
+			break
+		}
+	}
+	want := [][2]int64{{1, 1}, {3, 2}, {7, 5}, {17, 12}, {41, 29}, {99, 70}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Convergents(sqrt(2)) = %v, want %v", got, want)
+	}
+
+	// Project Euler 65: the digit sum of the numerator of the 100th convergent of e is 272
+	i := 0
+	for h := range Convergents(ContinuedFractionE()) {
+		i++
+		if i == 100 {
+			sum := 0
+			for _, c := range h.String() {
+				sum += int(c - '0')
+			}
+			if sum != 272 {
+				t.Errorf("digit sum of the 100th convergent numerator of e = %d, want 272", sum)
+			}
+			break
+		}
+	}
+	if got := collectTerms(PeriodicTerms(5, nil), 3); !reflect.DeepEqual(got, []int64{5}) {
+		t.Errorf("PeriodicTerms(5, nil) = %v, want [5]", got)
+	}
+}
+
+func collectTerms(terms iter.Seq[int64], n int) (res []int64) {
+	for a := range terms {
+		res = append(res, a)
+		if len(res) == n {
+			break
+		}
+	}
+	return
+}
+
+func TestBestApproximation(t *testing.T) {
+	pi := new(big.Rat).SetFloat64(math.Pi)
+	testCases := []struct {
+		x      *big.Rat
+		maxDen int64
+		want   string
+	}{
+		{pi, 1, "3"},
+		{pi, 7, "22/7"},
+		{pi, 100, "311/99"},
+		{pi, 1000, "355/113"},
+		{big.NewRat(3, 7), 100, "3/7"},
+		{big.NewRat(-1, 3), 2, "-1/2"},
+		// Project Euler 71: the fraction left of 3/7 is found as the best approximation from below
+		{new(big.Rat).SetFrac64(3000000-1, 7000000), 1000000, "428570/999997"},
+	}
+	for _, tc := range testCases {
+		if got := BestApproximation(tc.x, tc.maxDen); got.String() != tc.want {
+			t.Errorf("BestApproximation(%s, %d) = %s, want %s", tc.x.FloatString(8), tc.maxDen, got, tc.want)
+		}
+	}
+}
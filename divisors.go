@@ -0,0 +1,295 @@
+package eulerlib
+
+import (
+	"iter"
+	"slices"
+)
+
+// Abundance classifies a positive integer by comparing it with its aliquot sum
+type Abundance int
+
+const (
+	Deficient Abundance = iota - 1
+	Perfect
+	Abundant
+)
+
+func (a Abundance) String() string {
+	switch a {
+	case Deficient:
+		return "deficient"
+	case Perfect:
+		return "perfect"
+	case Abundant:
+		return "abundant"
+	default:
+		return "unknown"
+	}
+}
+
+// primePowers returns the distinct prime factors of n in ascending order
+// together with their exponents
+func primePowers[E Integer](n E) (primes []E, exps []int) {
+	for _, p := range PrimeFactors(n) {
+		if len(primes) > 0 && primes[len(primes)-1] == p {
+			exps[len(exps)-1]++
+			continue
+		}
+		primes = append(primes, p)
+		exps = append(exps, 1)
+	}
+	return
+}
+
+// CountDivisors returns the number of divisors of n
+func CountDivisors[E Integer](n E) E {
+	return SigmaK(n, 0)
+}
+
+// Divisors returns all divisors of n in ascending order.
+// The divisors are built from the prime factorization of n, so the work done
+// after factorizing is proportional to the number of divisors.
+// Returns nil for n < 1.
+func Divisors[E Integer](n E) []E {
+	if n < 1 {
+		return nil
+	}
+	res := []E{1}
+	ps, es := primePowers(n)
+	for i, p := range ps {
+		size := len(res)
+		pe := E(1)
+		for range es[i] {
+			pe *= p
+			for _, d := range res[:size] {
+				res = append(res, d*pe)
+			}
+		}
+	}
+	slices.Sort(res)
+	return res
+}
+
+// DivisorsSeq returns an iterator over all divisors of n without materializing them.
+// The divisors are yielded in factorization order, not in ascending order.
+//
+// Example:
+// for d := range DivisorsSeq(12) { ... }
+// // d takes the values 1, 3, 2, 6, 4, 12
+func DivisorsSeq[E Integer](n E) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		if n < 1 {
+			return
+		}
+		ps, es := primePowers(n)
+		var walk func(i int, d E) bool
+		walk = func(i int, d E) bool {
+			if i == len(ps) {
+				return yield(d)
+			}
+			for e := 0; e <= es[i]; e++ {
+				if !walk(i+1, d) {
+					return false
+				}
+				d *= ps[i]
+			}
+			return true
+		}
+		walk(0, 1)
+	}
+}
+
+// DivisorsUpTo returns all divisors of n that are <= k, in ascending order.
+// Branches of the factorization that exceed k are pruned instead of filtered.
+func DivisorsUpTo[E Integer](n E, k E) (res []E) {
+	if n < 1 || k < 1 {
+		return nil
+	}
+	ps, es := primePowers(n)
+	var walk func(i int, d E)
+	walk = func(i int, d E) {
+		if i == len(ps) {
+			res = append(res, d)
+			return
+		}
+		for e := 0; ; e++ {
+			walk(i+1, d)
+			if e == es[i] || d > k/ps[i] {
+				return
+			}
+			d *= ps[i]
+		}
+	}
+	walk(0, 1)
+	slices.Sort(res)
+	return
+}
+
+// UnitaryDivisors returns the divisors d of n with gcd(d, n/d) == 1, in ascending order.
+// These are exactly the products of subsets of the prime powers p^a exactly dividing n.
+func UnitaryDivisors[E Integer](n E) []E {
+	if n < 1 {
+		return nil
+	}
+	res := []E{1}
+	ps, es := primePowers(n)
+	for i, p := range ps {
+		pa := Pow(p, E(es[i]))
+		for _, d := range res {
+			res = append(res, d*pa)
+		}
+	}
+	slices.Sort(res)
+	return res
+}
+
+// SigmaK returns σ_k(n), the sum of the k-th powers of all divisors of n.
+// SigmaK(n, 0) is the number of divisors and SigmaK(n, 1) their sum.
+// It is computed from the prime factorization as the product of 1 + p^k + ... + p^(a·k).
+// Returns 0 for n < 1.
+func SigmaK[E Integer](n E, k int) E {
+	if n < 1 {
+		return 0
+	}
+	res := E(1)
+	ps, es := primePowers(n)
+	for i, p := range ps {
+		pk := Pow(p, E(k))
+		term, sum := E(1), E(1)
+		for range es[i] {
+			term *= pk
+			sum += term
+		}
+		res *= sum
+	}
+	return res
+}
+
+// Sigma returns σ(n), the sum of all divisors of n
+func Sigma[E Integer](n E) E {
+	return SigmaK(n, 1)
+}
+
+// AliquotSum returns s(n) = σ(n) - n, the sum of the proper divisors of n
+func AliquotSum[E Integer](n E) E {
+	if n < 1 {
+		return 0
+	}
+	return Sigma(n) - n
+}
+
+// Classify returns whether n is deficient, perfect or abundant.
+// It panics for n < 1.
+func Classify[E Integer](n E) Abundance {
+	if n < 1 {
+		panic("n must be positive")
+	}
+	s := AliquotSum(n)
+	switch {
+	case s < n:
+		return Deficient
+	case s > n:
+		return Abundant
+	default:
+		return Perfect
+	}
+}
+
+// ListSigmaK returns a slice where the value at every index i is σ_k(i), for all i <= n.
+// It sieves over the multiples of every d <= n, which takes O(n log n) time.
+func ListSigmaK[E Integer](n E, k int) []E {
+	if n < 0 {
+		panic("n must be non-negative")
+	}
+	res := make([]E, n+1)
+	for d := E(1); d <= n; d++ {
+		dk := Pow(d, E(k))
+		for m := d; m <= n; m += d {
+			res[m] += dk
+		}
+	}
+	return res
+}
+
+// ListSigma returns a slice where the value at every index i is σ(i), for all i <= n
+func ListSigma[E Integer](n E) []E {
+	return ListSigmaK(n, 1)
+}
+
+// ListAliquotSums returns a slice where the value at every index i is s(i), for all i <= n
+func ListAliquotSums[E Integer](n E) []E {
+	res := ListSigma(n)
+	for i := range res {
+		res[i] -= E(i)
+	}
+	return res
+}
+
+// AmicablePairs returns all amicable pairs (a, b) with a < b <= limit, ordered by a.
+// Two numbers are amicable when each is the aliquot sum of the other.
+func AmicablePairs[E Integer](limit E) (res [][2]E) {
+	s := ListAliquotSums(limit)
+	for a := E(1); a <= limit; a++ {
+		b := s[a]
+		if b > a && b <= limit && s[b] == a {
+			res = append(res, [2]E{a, b})
+		}
+	}
+	return
+}
+
+// AliquotSequence follows n, s(n), s(s(n)), ... until the sequence reaches 0,
+// exceeds limit or repeats a term. It returns the terms visited and the index
+// in seq where the repeating cycle starts, or -1 if the sequence does not cycle.
+//
+// Example:
+// seq, start := AliquotSequence(12496, 1000000)
+// // seq == [12496 14288 15472 14536 14264], start == 0
+func AliquotSequence[E Integer](n E, limit E) (seq []E, cycleStart int) {
+	seen := make(map[E]int)
+	for n > 0 && n <= limit {
+		if i, ok := seen[n]; ok {
+			return seq, i
+		}
+		seen[n] = len(seq)
+		seq = append(seq, n)
+		n = AliquotSum(n)
+	}
+	return seq, -1
+}
+
+// AliquotCycles returns all aliquot cycles whose members are all <= limit:
+// perfect numbers (length 1), amicable pairs (length 2) and sociable chains.
+// Every cycle is reported once, rotated to start at its smallest member,
+// and the cycles are ordered by that member.
+func AliquotCycles[E Integer](limit E) (res [][]E) {
+	s := ListAliquotSums(limit)
+	// owner records which starting value first visited a number, pos its index in that walk
+	owner := make([]E, limit+1)
+	pos := make([]int, limit+1)
+	for i := E(1); i <= limit; i++ {
+		if owner[i] != 0 {
+			continue
+		}
+		path := []E{}
+		x := i
+		for x > 0 && x <= limit && owner[x] == 0 {
+			owner[x] = i
+			pos[x] = len(path)
+			path = append(path, x)
+			x = s[x]
+		}
+		if x > 0 && x <= limit && owner[x] == i {
+			cycle := path[pos[x]:]
+			m := 0
+			for j, v := range cycle {
+				if v < cycle[m] {
+					m = j
+				}
+			}
+			res = append(res, append(append([]E{}, cycle[m:]...), cycle[:m]...))
+		}
+	}
+	Sort(res, func(a, b []E) bool { return a[0] < b[0] })
+	return
+}
@@ -0,0 +1,158 @@
+package eulerlib
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSigmaK(t *testing.T) {
+	testNums := [][]int64{{1, 1}, {12, 0}, {12, 1}, {12, 2}, {28, 1}, {97, 1}, {360, 1}, {0, 1}}
+	want := []int64{1, 6, 28, 210, 56, 98, 1170, 0}
+	for i, num := range testNums {
+		got := SigmaK(num[0], int(num[1]))
+		if got != want[i] {
+			t.Errorf("SigmaK(%d, %d) == %d, want %d", num[0], num[1], got, want[i])
+		}
+	}
+}
+
+func TestAliquotSum(t *testing.T) {
+	testNums := []int64{1, 6, 12, 220, 284, 945}
+	want := []int64{0, 6, 16, 284, 220, 975}
+	for i, num := range testNums {
+		got := AliquotSum(num)
+		if got != want[i] {
+			t.Errorf("AliquotSum(%d) == %d, want %d", num, got, want[i])
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	testNums := []int{1, 6, 8, 12, 28, 945, 496}
+	want := []Abundance{Deficient, Perfect, Deficient, Abundant, Perfect, Abundant, Perfect}
+	for i, num := range testNums {
+		got := Classify(num)
+		if got != want[i] {
+			t.Errorf("Classify(%d) == %s, want %s", num, got, want[i])
+		}
+	}
+	for _, num := range []int{0, -6} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Classify(%d) did not panic", num)
+				}
+			}()
+			Classify(num)
+		}()
+	}
+}
+
+func TestListSigmaK(t *testing.T) {
+	limit := 1000
+	for k := 0; k <= 2; k++ {
+		got := ListSigmaK(limit, k)
+		for i := 1; i <= limit; i++ {
+			if got[i] != SigmaK(i, k) {
+				t.Errorf("ListSigmaK(%d, %d)[%d] == %d, want %d", limit, k, i, got[i], SigmaK(i, k))
+			}
+		}
+	}
+}
+
+func TestAmicablePairs(t *testing.T) {
+	got := AmicablePairs(10000)
+	want := [][2]int{{220, 284}, {1184, 1210}, {2620, 2924}, {5020, 5564}, {6232, 6368}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AmicablePairs(10000) == %v, want %v", got, want)
+	}
+}
+
+func TestAliquotSequence(t *testing.T) {
+	seq, start := AliquotSequence(12496, 1000000)
+	if !reflect.DeepEqual(seq, []int{12496, 14288, 15472, 14536, 14264}) || start != 0 {
+		t.Errorf("AliquotSequence(12496) == %v, %d", seq, start)
+	}
+	seq, start = AliquotSequence(95, 1000)
+	if !reflect.DeepEqual(seq, []int{95, 25, 6}) || start != 2 {
+		t.Errorf("AliquotSequence(95) == %v, %d", seq, start)
+	}
+	seq, start = AliquotSequence(10, 1000)
+	if !reflect.DeepEqual(seq, []int{10, 8, 7, 1}) || start != -1 {
+		t.Errorf("AliquotSequence(10) == %v, %d", seq, start)
+	}
+}
+
+func TestAliquotCycles(t *testing.T) {
+	got := AliquotCycles(20000)
+	want := [][]int{
+		{6}, {28}, {220, 284}, {496}, {1184, 1210}, {2620, 2924}, {5020, 5564}, {6232, 6368},
+		{8128}, {10744, 10856}, {12285, 14595}, {12496, 14288, 15472, 14536, 14264}, {17296, 18416},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AliquotCycles(20000) == %v, want %v", got, want)
+	}
+}
+
+func TestDivisors(t *testing.T) {
+	testNums := []int64{1, 2, 12, 28, 97, 360}
+	want := [][]int64{
+		{1},
+		{1, 2},
+		{1, 2, 3, 4, 6, 12},
+		{1, 2, 4, 7, 14, 28},
+		{1, 97},
+		{1, 2, 3, 4, 5, 6, 8, 9, 10, 12, 15, 18, 20, 24, 30, 36, 40, 45, 60, 72, 90, 120, 180, 360},
+	}
+	for i, num := range testNums {
+		got := Divisors(num)
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("Divisors(%d) == %v, want %v", num, got, want[i])
+		}
+		if c := CountDivisors(num); c != int64(len(want[i])) {
+			t.Errorf("CountDivisors(%d) == %d, want %d", num, c, len(want[i]))
+		}
+	}
+	if got := Divisors(0); got != nil {
+		t.Errorf("Divisors(0) == %v, want nil", got)
+	}
+}
+
+func TestDivisorsSeq(t *testing.T) {
+	for n := 1; n <= 500; n++ {
+		got := slices.Sorted(DivisorsSeq(n))
+		if !reflect.DeepEqual(got, Divisors(n)) {
+			t.Errorf("DivisorsSeq(%d) == %v, want %v", n, got, Divisors(n))
+		}
+	}
+	count := 0
+	for range DivisorsSeq(720720) {
+		count++
+		if count == 5 {
+			break
+		}
+	}
+	if count != 5 {
+		t.Errorf("DivisorsSeq did not stop after break, count == %d", count)
+	}
+}
+
+func TestDivisorsUpTo(t *testing.T) {
+	got := DivisorsUpTo(360, 20)
+	want := []int{1, 2, 3, 4, 5, 6, 8, 9, 10, 12, 15, 18, 20}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DivisorsUpTo(360, 20) == %v, want %v", got, want)
+	}
+	if got := DivisorsUpTo(uint8(255), 255); len(got) != 8 {
+		t.Errorf("DivisorsUpTo(255, 255) == %v, want 8 divisors", got)
+	}
+}
+
+func TestUnitaryDivisors(t *testing.T) {
+	got := UnitaryDivisors(360)
+	want := []int{1, 5, 8, 9, 40, 45, 72, 360}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnitaryDivisors(360) == %v, want %v", got, want)
+	}
+}
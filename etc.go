@@ -238,11 +238,18 @@ func Totient[E Integer](n E) E {
 	return res
 }
 
-// Lists totients
+// Lists the totients of all integers up to n using a sieve
 func ListTotients[E Integer](n E) []E {
 	res := make([]E, n+1)
-	for i := E(1); i <= n; i++ {
-		res[i] = Totient(i)
+	for i := range res {
+		res[i] = E(i)
+	}
+	for p := E(2); p <= n; p++ {
+		if res[p] == p {
+			for m := p; m <= n; m += p {
+				res[m] -= res[m] / p
+			}
+		}
 	}
 	return res
 }
@@ -0,0 +1,166 @@
+package eulerlib
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// FactorialBig returns n! using the prime swing algorithm: n! = ((n/2)!)²·swing(n), where the
+// swing number n!/((n/2)!)² is the product of the prime powers p^e with e the number of odd
+// quotients n/p^i. The products are balanced with a product tree, so the cost is dominated by
+// a few multiplications of large numbers instead of n multiplications by small ones.
+// It panics for negative n.
+func FactorialBig(n int64) *big.Int {
+	if n < 0 {
+		panic("n must not be negative")
+	}
+	if n < 20 {
+		return big.NewInt(Factorial(n))
+	}
+	return factorialSwing(n, ListPrimes(n))
+}
+
+// FactorialDigitSumBase returns the sum of the digits of n! written in the given base (2..62)
+//
+// Example:
+// s := FactorialDigitSumBase(100, 10)
+// // s == 648
+func FactorialDigitSumBase(n int64, base int) (res int64) {
+	if base < 2 || base > big.MaxBase {
+		panic("base must be in [2, 62]")
+	}
+	for _, c := range FactorialBig(n).Text(base) {
+		switch {
+		case c >= '0' && c <= '9':
+			res += int64(c - '0')
+		case c >= 'a' && c <= 'z':
+			res += int64(c-'a') + 10
+		default:
+			res += int64(c-'A') + 36
+		}
+	}
+	return
+}
+
+// FactorialTrailingZeros returns the number of trailing zeros of n! written in the given
+// base, which is the smallest LegendreValuation(n, p)/e over the prime powers p^e of base
+//
+// Example:
+// z := FactorialTrailingZeros(100, 10)
+// // z == 24
+func FactorialTrailingZeros(n, base int64) int64 {
+	if n < 0 || base < 2 {
+		panic("FactorialTrailingZeros requires n >= 0 and base >= 2")
+	}
+	res := int64(-1)
+	ps, es := primePowers(base)
+	for i, p := range ps {
+		if z := LegendreValuation(n, p) / int64(es[i]); res < 0 || z < res {
+			res = z
+		}
+	}
+	return res
+}
+
+// FactorialMod returns n! mod p for a prime p, which is 0 for n >= p. For n > p/2 it uses
+// Wilson's theorem (p-1)! ≡ -1 and divides by the factors above n, so it takes
+// O(min(n, p-n)) multiplications.
+func FactorialMod(n, p int64) int64 {
+	if n < 0 || p < 2 {
+		panic("FactorialMod requires n >= 0 and a prime p")
+	}
+	if n >= p {
+		return 0
+	}
+	res := 1 % p
+	if n <= p/2 {
+		for i := int64(2); i <= n; i++ {
+			res = mulMod(res, i, p)
+		}
+		return res
+	}
+	for i := n + 1; i < p; i++ {
+		res = mulMod(res, i, p)
+	}
+	return normMod(-invMod(res, p), p)
+}
+
+// FactorialUnitMod splits n! into p^e·u with u not divisible by the prime p, and returns
+// u mod p together with e. It applies (n!)_p ≡ (-1)^(n/p)·(n mod p)!·((n/p)!)_p, which
+// follows from Wilson's theorem, once per base p digit of n, so n may be far larger than p.
+//
+// Example:
+// u, e := FactorialUnitMod(100, 5) // 100! == 5^24·u
+// // u == 4, e == 24
+func FactorialUnitMod(n, p int64) (u, e int64) {
+	if n < 0 || p < 2 {
+		panic("FactorialUnitMod requires n >= 0 and a prime p")
+	}
+	var table *FactorialTable
+	if p <= lucasTableLimit && n >= p {
+		table = NewFactorialTable(int(p-1), p)
+	}
+	u = 1 % p
+	for m := n; m > 0; m /= p {
+		r := m % p
+		if table != nil {
+			u = mulMod(u, table.Factorial(int(r)), p)
+		} else {
+			u = mulMod(u, FactorialMod(r, p), p)
+		}
+		if (m/p)%2 == 1 {
+			u = normMod(-u, p)
+		}
+	}
+	return u, LegendreValuation(n, p)
+}
+
+// factorialSwing returns n! given all primes up to n
+func factorialSwing(n int64, primes []int64) *big.Int {
+	if n < 20 {
+		return big.NewInt(Factorial(n))
+	}
+	res := factorialSwing(n/2, primes)
+	res.Mul(res, res)
+	return res.Mul(res, productTree(swingFactors(n, primes)))
+}
+
+// swingFactors returns the prime powers whose product is the swing number of n
+func swingFactors(n int64, primes []int64) (factors []int64) {
+	for _, p := range primes {
+		if p > n {
+			break
+		}
+		pe := int64(1)
+		for q := n / p; q > 0; q /= p {
+			if q&1 == 1 {
+				pe *= p
+			}
+		}
+		if pe > 1 {
+			factors = append(factors, pe)
+		}
+	}
+	return
+}
+
+// productTree returns the product of nums, multiplying halves of similar size
+func productTree(nums []int64) *big.Int {
+	if len(nums) <= 16 {
+		res := big.NewInt(1)
+		// accumulate in a machine word as long as the product fits
+		acc := uint64(1)
+		for _, v := range nums {
+			if hi, lo := bits.Mul64(acc, uint64(v)); hi == 0 {
+				acc = lo
+				continue
+			}
+			res.Mul(res, new(big.Int).SetUint64(acc))
+			acc = uint64(v)
+		}
+		return res.Mul(res, new(big.Int).SetUint64(acc))
+	}
+	mid := len(nums) / 2
+	left := productTree(nums[:mid])
+	return left.Mul(left, productTree(nums[mid:]))
+}
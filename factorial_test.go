@@ -0,0 +1,97 @@
+package eulerlib
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFactorialBig(t *testing.T) {
+	for _, n := range []int64{0, 1, 5, 19, 20, 21, 63, 64, 100, 1000, 12345, 100000} {
+		want := new(big.Int).MulRange(1, n)
+		if got := FactorialBig(n); got.Cmp(want) != 0 {
+			t.Errorf("FactorialBig(%d) is wrong, got %d bits, want %d bits", n, got.BitLen(), want.BitLen())
+		}
+	}
+	if got := FactorialBigInt(-1); got.Int64() != 1 {
+		t.Errorf("FactorialBigInt(-1) == %s, want 1", got)
+	}
+}
+
+func TestDigitFactorialSum(t *testing.T) {
+	testNums := []int{0, 1, 145, 40585, 169}
+	want := []int{1, 1, 145, 40585, 363601}
+	for i, n := range testNums {
+		if got := DigitFactorialSum(n); got != want[i] {
+			t.Errorf("DigitFactorialSum(%d) == %d, want %d", n, got, want[i])
+		}
+	}
+}
+
+func TestFactorialDigitSumBase(t *testing.T) {
+	tests := []struct {
+		n    int64
+		base int
+		want int64
+	}{
+		{100, 10, 648},
+		{1000, 2, 3788},
+		{1000, 16, 14070},
+		{1000, 62, 42883},
+		{0, 10, 1},
+	}
+	for _, tt := range tests {
+		if got := FactorialDigitSumBase(tt.n, tt.base); got != tt.want {
+			t.Errorf("FactorialDigitSumBase(%d, %d) == %d, want %d", tt.n, tt.base, got, tt.want)
+		}
+	}
+}
+
+func TestFactorialTrailingZeros(t *testing.T) {
+	tests := [][3]int64{{100, 10, 24}, {1000, 12, 497}, {1000, 36, 249}, {1000, 7, 164}, {1000, 16, 248}, {4, 10, 0}}
+	for _, tt := range tests {
+		if got := FactorialTrailingZeros(tt[0], tt[1]); got != tt[2] {
+			t.Errorf("FactorialTrailingZeros(%d, %d) == %d, want %d", tt[0], tt[1], got, tt[2])
+		}
+	}
+}
+
+func TestFactorialMod(t *testing.T) {
+	const p = 1000003
+	table := NewFactorialTable(p-1, p)
+	for _, n := range []int64{0, 1, 2, 1000, 500001, 500002, 999999, p - 1} {
+		if got := FactorialMod(n, p); got != table.Factorial(int(n)) {
+			t.Errorf("FactorialMod(%d, %d) == %d, want %d", n, p, got, table.Factorial(int(n)))
+		}
+	}
+	if got := FactorialMod(p, p); got != 0 {
+		t.Errorf("FactorialMod(%d, %d) == %d, want 0", p, p, got)
+	}
+	// Wilson's theorem gives (p-2)! ≡ 1
+	if got := FactorialMod(1000000005, 1000000007); got != 1 {
+		t.Errorf("FactorialMod(10^9+5, 10^9+7) == %d, want 1", got)
+	}
+}
+
+func TestFactorialUnitMod(t *testing.T) {
+	tests := [][4]int64{{100, 5, 4, 24}, {123456, 1009, 361, 122}, {0, 7, 1, 0}}
+	for _, tt := range tests {
+		if u, e := FactorialUnitMod(tt[0], tt[1]); u != tt[2] || e != tt[3] {
+			t.Errorf("FactorialUnitMod(%d, %d) == %d, %d, want %d, %d", tt[0], tt[1], u, e, tt[2], tt[3])
+		}
+	}
+	// compare with the exact factorial for a few primes
+	for _, p := range []int64{2, 3, 13} {
+		for n := int64(0); n < 200; n += 11 {
+			f := FactorialBig(n)
+			e := int64(0)
+			for new(big.Int).Mod(f, big.NewInt(p)).Sign() == 0 {
+				f.Quo(f, big.NewInt(p))
+				e++
+			}
+			want := new(big.Int).Mod(f, big.NewInt(p)).Int64()
+			if u, ge := FactorialUnitMod(n, p); u != want || ge != e {
+				t.Errorf("FactorialUnitMod(%d, %d) == %d, %d, want %d, %d", n, p, u, ge, want, e)
+			}
+		}
+	}
+}
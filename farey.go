@@ -0,0 +1,184 @@
+package eulerlib
+
+import (
+	"errors"
+	"iter"
+	"math"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// FareySequence returns an iterator over the Farey sequence of order n: all reduced
+// fractions a/b in [0, 1] with b <= n in increasing order, as (a, b) pairs.
+// Every term follows from the previous two by the next-term formula
+// c/d = (k·c' - a)/(k·d' - b) with k = floor((n + b) / d').
+func FareySequence(n int64) iter.Seq2[int64, int64] {
+	return func(yield func(int64, int64) bool) {
+		if n < 1 {
+			return
+		}
+		a, b, c, d := int64(0), int64(1), int64(1), n
+		if !yield(a, b) {
+			return
+		}
+		for c <= n {
+			if !yield(c, d) || c == d {
+				return
+			}
+			k := (n + b) / d
+			a, b, c, d = c, d, k*c-a, k*d-b
+		}
+	}
+}
+
+// FareyLength returns the number of terms of the Farey sequence of order n, 1 + Σ_{k<=n} φ(k)
+func FareyLength(n int64) *big.Int {
+	res := TotientSum(n)
+	return res.Add(res, big.NewInt(1))
+}
+
+// FareyNeighbors returns the fractions of the Farey sequence of order n directly to the left
+// and to the right of x, for 0 < x < 1. x does not need to be in the sequence itself.
+// It descends the Stern–Brocot tree in runs, so it takes O(log n) steps.
+//
+// Example:
+// left, _ := FareyNeighbors(NewRational[int64](3, 7), 1000000)
+// // left == 428570/999997
+func FareyNeighbors(x Rational[int64], n int64) (left, right Rational[int64]) {
+	a, b, ok := x.Small()
+	if !ok || a <= 0 || a >= b {
+		panic("x must lie strictly between 0 and 1")
+	}
+	if n < 1 {
+		panic("n must be positive")
+	}
+	lp, lq, rp, rq := int64(0), int64(1), int64(1), int64(1)
+	for lq+rq <= n {
+		mp, mq := lp+rp, lq+rq
+		switch NewRational(mp, mq).Cmp(x) {
+		case -1:
+			// move the left bound towards x as far as possible in one run
+			k := min((a*lq-b*lp-1)/(b*rp-a*rq), (n-lq)/rq)
+			lp, lq = lp+k*rp, lq+k*rq
+		case 1:
+			k := min((b*rp-a*rq-1)/(a*lq-b*lp), (n-rq)/lq)
+			rp, rq = rp+k*lp, rq+k*lq
+		default:
+			// x is in the sequence, its neighbors are the furthest steps from the parents towards x
+			k := (n - lq) / b
+			left = NewRational(lp+k*a, lq+k*b)
+			k = (n - rq) / b
+			right = NewRational(rp+k*a, rq+k*b)
+			return
+		}
+	}
+	return NewRational(lp, lq), NewRational(rp, rq)
+}
+
+// CountFareyBetween returns the number of reduced fractions p/q with q <= n strictly between
+// x and y. For every denominator q there are floor(y·q) - floor(x·q) fractions, reduced or not,
+// and the reduced ones are separated out with a divisor sieve in O(n log n) time.
+// The sieve holds one int64 per denominator, so it needs 8(n+1) bytes of memory.
+func CountFareyBetween(x, y Rational[int64], n int64) int64 {
+	switch x.Cmp(y) {
+	case 0:
+		return 0
+	case 1:
+		x, y = y, x
+	}
+	res := countFareyUpTo(y, n) - countFareyUpTo(x, n)
+	if y.Den().Cmp(big.NewInt(n)) <= 0 {
+		// y itself is counted by countFareyUpTo(y, n)
+		res--
+	}
+	return res
+}
+
+// countFareyUpTo returns the number of reduced fractions 0 < p/q <= x with q <= n
+func countFareyUpTo(x Rational[int64], n int64) int64 {
+	a, b, ok := x.Small()
+	if !ok {
+		panic("x must fit in int64")
+	}
+	// counts[q] starts as all p/q <= x and ends as the reduced ones, since every
+	// fraction with denominator q reduces to one with a denominator dividing q
+	counts := make([]int64, n+1)
+	for q := int64(1); q <= n; q++ {
+		counts[q] += mulFloorDiv(a, q, b)
+		for m := 2 * q; m <= n; m += q {
+			counts[m] -= counts[q]
+		}
+	}
+	return Sum(counts)
+}
+
+// mulFloorDiv returns floor(a·q / b) for q >= 0 and b > 0, using a 128-bit product.
+// It panics if the result does not fit in an int64.
+func mulFloorDiv(a, q, b int64) int64 {
+	neg := a < 0
+	if neg {
+		a = -a
+	}
+	hi, lo := bits.Mul64(uint64(a), uint64(q))
+	if hi >= uint64(b) {
+		panic("a·q / b does not fit in int64")
+	}
+	quo, rem := bits.Div64(hi, lo, uint64(b))
+	if quo > math.MaxInt64 {
+		panic("a·q / b does not fit in int64")
+	}
+	if neg {
+		if rem != 0 {
+			quo++
+		}
+		return -int64(quo)
+	}
+	return int64(quo)
+}
+
+// SternBrocotPath returns the path from the root 1/1 of the Stern–Brocot tree to the
+// positive fraction x, as a string of 'L' and 'R' moves. The run lengths of the path
+// are the terms of the continued fraction of x, with the last one reduced by 1.
+//
+// Example:
+// s := SternBrocotPath(NewRational[int64](3, 7))
+// // s == "LLRR"
+func SternBrocotPath(x Rational[int64]) string {
+	if _, _, ok := x.Small(); !ok || x.Sign() <= 0 {
+		panic("x must be positive")
+	}
+	var sb strings.Builder
+	lp, lq, rp, rq := int64(0), int64(1), int64(1), int64(0)
+	for {
+		mp, mq := lp+rp, lq+rq
+		switch NewRational(mp, mq).Cmp(x) {
+		case 1:
+			sb.WriteByte('L')
+			rp, rq = mp, mq
+		case -1:
+			sb.WriteByte('R')
+			lp, lq = mp, mq
+		default:
+			return sb.String()
+		}
+	}
+}
+
+// SternBrocotFromPath is the inverse of SternBrocotPath. It returns an error if the path
+// contains anything but 'L' and 'R'.
+func SternBrocotFromPath(path string) (Rational[int64], error) {
+	lp, lq, rp, rq := int64(0), int64(1), int64(1), int64(0)
+	for _, c := range path {
+		mp, mq := lp+rp, lq+rq
+		switch c {
+		case 'L':
+			rp, rq = mp, mq
+		case 'R':
+			lp, lq = mp, mq
+		default:
+			return Rational[int64]{}, errors.New("stern-brocot path contains a move other than L or R")
+		}
+	}
+	return NewRational(lp+rp, lq+rq), nil
+}
@@ -0,0 +1,125 @@
+package eulerlib
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFareySequence(t *testing.T) {
+	got := ""
+	for a, b := range FareySequence(5) {
+		got += NewRational(a, b).String() + " "
+	}
+	want := "0 1/5 1/4 1/3 2/5 1/2 3/5 2/3 3/4 4/5 1 "
+	if got != want {
+		t.Errorf("FareySequence(5) = %s, want %s", got, want)
+	}
+	for n := int64(1); n <= 200; n++ {
+		count := int64(0)
+		for range FareySequence(n) {
+			count++
+		}
+		if want := FareyLength(n); want.Int64() != count {
+			t.Errorf("FareySequence(%d) has %d terms, want %s", n, count, want)
+		}
+	}
+}
+
+func TestFareyNeighbors(t *testing.T) {
+	testCases := []struct {
+		x           Rational[int64]
+		n           int64
+		left, right string
+	}{
+		{NewRational[int64](1, 2), 5, "2/5", "3/5"},
+		{NewRational[int64](1, 3), 8, "2/7", "3/8"},
+		{NewRational[int64](3, 7), 8, "2/5", "1/2"},
+		{NewRational[int64](3, 7), 1000000, "428570/999997", "428569/999994"},
+		{NewRational[int64](5, 17), 8, "2/7", "1/3"},
+		{NewRational[int64](1, 100), 8, "0", "1/8"},
+	}
+	for _, tc := range testCases {
+		left, right := FareyNeighbors(tc.x, tc.n)
+		if left.String() != tc.left || right.String() != tc.right {
+			t.Errorf("FareyNeighbors(%s, %d) = %s, %s, want %s, %s", tc.x, tc.n, left, right, tc.left, tc.right)
+		}
+	}
+	// compare with a walk over the sequence
+	n := int64(30)
+	terms := []Rational[int64]{}
+	for a, b := range FareySequence(n) {
+		terms = append(terms, NewRational(a, b))
+	}
+	for i := 1; i+1 < len(terms); i++ {
+		left, right := FareyNeighbors(terms[i], n)
+		if !left.Equal(terms[i-1]) || !right.Equal(terms[i+1]) {
+			t.Errorf("FareyNeighbors(%s, %d) = %s, %s, want %s, %s", terms[i], n, left, right, terms[i-1], terms[i+1])
+		}
+	}
+}
+
+func TestCountFareyBetween(t *testing.T) {
+	// Project Euler 73
+	if got := CountFareyBetween(NewRational[int64](1, 3), NewRational[int64](1, 2), 12000); got != 7295372 {
+		t.Errorf("CountFareyBetween(1/3, 1/2, 12000) = %d, want 7295372", got)
+	}
+	if got := CountFareyBetween(NewRational[int64](1, 3), NewRational[int64](1, 2), 8); got != 3 {
+		t.Errorf("CountFareyBetween(1/3, 1/2, 8) = %d, want 3", got)
+	}
+	if got := CountFareyBetween(NewRational[int64](1, 10), NewRational[int64](1, 9), 8); got != 0 {
+		t.Errorf("CountFareyBetween(1/10, 1/9, 8) = %d, want 0", got)
+	}
+	if got := CountFareyBetween(NewRational[int64](1, 2), NewRational[int64](1, 2), 5); got != 0 {
+		t.Errorf("CountFareyBetween(1/2, 1/2, 5) = %d, want 0", got)
+	}
+	if got := CountFareyBetween(NewRational[int64](1, 2), NewRational[int64](1, 3), 8); got != 3 {
+		t.Errorf("CountFareyBetween(1/2, 1/3, 8) = %d, want 3", got)
+	}
+	// numerator·denominator overflows int64
+	near := NewRational[int64](1000000000000000000, 1000000000000000001)
+	if got := CountFareyBetween(NewRational[int64](0, 1), near, 10); got != 31 {
+		t.Errorf("CountFareyBetween(0, 10^18/(10^18+1), 10) = %d, want 31", got)
+	}
+	if got := CountFareyBetween(near, NewRational[int64](1, 1), 10); got != 0 {
+		t.Errorf("CountFareyBetween(10^18/(10^18+1), 1, 10) = %d, want 0", got)
+	}
+}
+
+func TestMulFloorDiv(t *testing.T) {
+	tests := [][4]int64{
+		{7, 3, 2, 10},
+		{-7, 3, 2, -11},
+		{-6, 3, 2, -9},
+		{1000000000, 10000000000, 1000000001, 9999999990},
+		{-1000000000, 10000000000, 1000000001, -9999999991},
+		{math.MaxInt64, math.MaxInt64, math.MaxInt64, math.MaxInt64},
+	}
+	for _, tt := range tests {
+		if got := mulFloorDiv(tt[0], tt[1], tt[2]); got != tt[3] {
+			t.Errorf("mulFloorDiv(%d, %d, %d) = %d, want %d", tt[0], tt[1], tt[2], got, tt[3])
+		}
+	}
+}
+
+func TestSternBrocotPath(t *testing.T) {
+	testCases := []struct {
+		x    Rational[int64]
+		path string
+	}{
+		{NewRational[int64](1, 1), ""},
+		{NewRational[int64](3, 7), "LLRR"},
+		{NewRational[int64](5, 2), "RRL"},
+		{NewRational[int64](1, 4), "LLL"},
+	}
+	for _, tc := range testCases {
+		if got := SternBrocotPath(tc.x); got != tc.path {
+			t.Errorf("SternBrocotPath(%s) = %s, want %s", tc.x, got, tc.path)
+		}
+		if got, err := SternBrocotFromPath(tc.path); err != nil || !got.Equal(tc.x) {
+			t.Errorf("SternBrocotFromPath(%s) = %s, %v, want %s", tc.path, got, err, tc.x)
+		}
+	}
+	if _, err := SternBrocotFromPath("LXR"); err == nil {
+		t.Errorf("SternBrocotFromPath(\"LXR\") did not report the invalid move")
+	}
+}
@@ -1,103 +1,229 @@
 package eulerlib
 
 import (
-	"math"
+	"iter"
 	"math/big"
+	"math/bits"
 )
 
-// Generates the Fibonaccisequence
+// All functions in this file index the Fibonacci sequence as F(0) = 0, F(1) = 1
+// and F(n) = F(n-1) + F(n-2), so the element at index i of a returned slice is F(i).
+
+// FibonacciSeq returns an iterator over the pairs (n, F(n)) for all F(n) <= limit,
+// starting at F(0). The iteration also stops before F(n) would overflow int64.
+func FibonacciSeq(limit int64) iter.Seq2[int, int64] {
+	return func(yield func(int, int64) bool) {
+		a, b := int64(0), int64(1)
+		for n := 0; a <= limit; n++ {
+			if !yield(n, a) || b < a {
+				return
+			}
+			a, b = b, a+b
+		}
+	}
+}
+
+// FibonacciUpTo returns all Fibonacci numbers F(0), F(1), ... that are <= limit
+//
+// Example:
+// s := FibonacciUpTo(10)
+// // s == [0 1 1 2 3 5 8]
+func FibonacciUpTo(limit int64) (res []int64) {
+	for _, f := range FibonacciSeq(limit) {
+		res = append(res, f)
+	}
+	return
+}
+
+// FibonacciN returns the first count Fibonacci numbers F(0), ..., F(count-1).
+// Results beyond F(92) wrap around int64, use FibonacciBigN for those.
+func FibonacciN(count int) []int64 {
+	res := make([]int64, max(count, 0))
+	for i := range res {
+		if i < 2 {
+			res[i] = int64(i)
+		} else {
+			res[i] = res[i-1] + res[i-2]
+		}
+	}
+	return res
+}
+
+// FibonacciBigUpTo returns all Fibonacci numbers F(0), F(1), ... that are <= limit as Big Integers
+func FibonacciBigUpTo(limit *big.Int) (res []*big.Int) {
+	a, b := big.NewInt(0), big.NewInt(1)
+	for a.Cmp(limit) <= 0 {
+		res = append(res, a)
+		a, b = b, new(big.Int).Add(a, b)
+	}
+	return
+}
+
+// FibonacciBigN returns the first count Fibonacci numbers F(0), ..., F(count-1) as Big Integers
+func FibonacciBigN(count int) []*big.Int {
+	res := make([]*big.Int, max(count, 0))
+	for i := range res {
+		if i < 2 {
+			res[i] = big.NewInt(int64(i))
+		} else {
+			res[i] = new(big.Int).Add(res[i-1], res[i-2])
+		}
+	}
+	return res
+}
+
+// GenFibo generates the Fibonacci numbers F(2), ..., F(limit+5) on a channel.
+//
+// Deprecated: Use FibonacciSeq or FibonacciN, which start at F(0).
 func GenFibo(limit int64) <-chan int64 {
 	chnl := make(chan int64)
-	fibos := []int64{1, 2, 3}
 	go func() {
-		for i := 0; int64(i) <= limit+3; i++ {
-			fibos = append(fibos, fibos[len(fibos)-1]+fibos[len(fibos)-2])
-			chnl <- fibos[i]
+		for _, f := range Fibonacci(limit) {
+			chnl <- f
 		}
-
 		close(chnl)
 	}()
 	return chnl
 }
 
-// Returns a slice with the first x Fibonacci numbers
-func Fibonacci(limit int64) (res []int64) {
-	for x := range GenFibo(limit) {
-		res = append(res, x)
+// Fibonacci returns a slice with the Fibonacci numbers F(2), ..., F(limit+5).
+//
+// Deprecated: Use FibonacciN or FibonacciUpTo, which start at F(0).
+func Fibonacci(limit int64) []int64 {
+	if limit < -3 {
+		return nil
 	}
-	return
+	return FibonacciN(int(limit) + 6)[2:]
 }
 
-// FibonacciSingle returns the nth fibonacci number.
-// The result is the same as the last element of Fibonacci(n)
-// but calculated while using less memory
+// FibonacciSingle returns F(n) as an int64. F(93) and beyond do not fit in an int64,
+// for those the result wraps around like int64 addition, which is F(n) mod 2^64
+// reinterpreted as signed. Use Fib for the exact value.
+//
+// Deprecated: Use Fib for exact results or FibMod for modular ones.
 func FibonacciSingle[E Integer](n E) int64 {
 	if n < 2 {
 		return int64(n)
 	}
-	it := E(2)
-	fibs := []E{1, 1}
-	for it < n {
-		tmp := fibs[0]
-		fibs = fibs[1:]
-		fibs = append(fibs, fibs[0]+tmp)
-		it++
+	// fast doubling in uint64, whose arithmetic is exact modulo 2^64
+	a, b := uint64(0), uint64(1)
+	for i := bits.Len64(uint64(n)) - 1; i >= 0; i-- {
+		c, d := a*(2*b-a), a*a+b*b
+		if (uint64(n)>>i)&1 == 1 {
+			a, b = d, c+d
+		} else {
+			a, b = c, d
+		}
 	}
-	return int64(fibs[1])
+	return int64(a)
 }
 
-// Generates the Fibonaccisequence in Big Integer
+// GenFiboBig generates the Fibonacci numbers F(0), ..., F(limit+3) on a channel.
+//
+// Deprecated: Use FibonacciBigN or FibonacciBigUpTo.
 func GenFiboBig(limit int64) <-chan big.Int {
 	chnl := make(chan big.Int)
-	fibos := []big.Int{*big.NewInt(0), *big.NewInt(1)}
 	go func() {
-		for i := int64(0); i <= limit+3; i++ {
-			fibos = append(fibos, *new(big.Int).Add(&fibos[len(fibos)-1], &fibos[len(fibos)-2]))
-			chnl <- fibos[i]
+		for _, f := range FibonacciBigN(int(limit) + 4) {
+			chnl <- *f
 		}
-
 		close(chnl)
 	}()
 	return chnl
 }
 
-// Returns a slice with the first x Fibonacci numbers in Big Integer
+// FibonacciBig returns a slice with the Fibonacci numbers F(0), ..., F(limit+5) as Big Integers.
+//
+// Deprecated: Use FibonacciBigN or FibonacciBigUpTo.
 func FibonacciBig(limit int64) (res []big.Int) {
-	res = []big.Int{*big.NewInt(0), *big.NewInt(1)}
-	for i := int64(0); i <= limit+3; i++ {
-		res = append(res, *new(big.Int).Add(&res[len(res)-1], &res[len(res)-2]))
+	for _, f := range FibonacciBigN(int(limit) + 6) {
+		res = append(res, *f)
 	}
 	return
 }
 
+// FibonacciSingleBig returns F(n) as a Big Integer.
+//
+// Deprecated: Use Fib.
 func FibonacciSingleBig(n int64) *big.Int {
 	if n < 2 {
 		return big.NewInt(n)
 	}
-	temp1 := big.NewFloat(1 + math.Sqrt(5))
-	temp2 := big.NewFloat(1 - math.Sqrt(5))
-	temp3 := powBigFloat(big.NewFloat(2), uint64(n))
-	temp3.Mul(temp3, big.NewFloat(math.Sqrt(5)))
+	return Fib(n)
+}
 
-	temp1 = powBigFloat(temp1, uint64(n))
-	temp2 = powBigFloat(temp2, uint64(n))
-	temp1.Sub(temp1, temp2)
-	temp1.Quo(temp1, temp3)
+// Fib returns the nth Fibonacci number F(n) exactly, with F(0) = 0 and F(1) = 1,
+// using the fast doubling identities
+// F(2k) = F(k)·(2F(k+1) - F(k)) and F(2k+1) = F(k)² + F(k+1)².
+// Negative indices follow F(-n) = (-1)^(n+1)·F(n).
+func Fib(n int64) *big.Int {
+	a, _ := fibPair(n)
+	return a
+}
 
-	res, _ := temp1.Int(nil)
+// LucasNumber returns the nth Lucas number L(n) exactly, with L(0) = 2 and L(1) = 1.
+// It is computed as L(n) = 2F(n+1) - F(n) from the fast doubling pair.
+// Negative indices follow L(-n) = (-1)^n·L(n).
+func LucasNumber(n int64) *big.Int {
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+	a, b := fibPair(n)
+	res := b.Lsh(b, 1).Sub(b, a)
+	if neg && n%2 == 1 {
+		res.Neg(res)
+	}
 	return res
 }
 
-func powBigFloat(a *big.Float, e uint64) *big.Float {
-	result := zero().Copy(a)
-	for i := uint64(0); i < e-1; i++ {
-		result.Mul(result, a)
+// FibMod returns F(n) mod m for 0 <= n and m >= 1, using fast doubling.
+// All intermediate products are reduced with 128-bit arithmetic, so any int64 modulus works.
+func FibMod(n, m int64) int64 {
+	if n < 0 || m < 1 {
+		panic("FibMod requires n >= 0 and m >= 1")
 	}
-	return result
+	a, b := int64(0), 1%m
+	for i := bits.Len64(uint64(n)) - 1; i >= 0; i-- {
+		c := mulMod(a, int64((2*uint64(b)%uint64(m)+uint64(m-a))%uint64(m)), m)
+		d := (uint64(mulMod(a, a, m)) + uint64(mulMod(b, b, m))) % uint64(m)
+		if (n>>i)&1 == 1 {
+			a, b = int64(d), int64((uint64(c)+d)%uint64(m))
+		} else {
+			a, b = c, int64(d)
+		}
+	}
+	return a
 }
 
-func zero() *big.Float {
-	r := big.NewFloat(0.0)
-	r.SetPrec(256)
-	return r
+// fibPair returns F(n) and F(n+1)
+func fibPair(n int64) (*big.Int, *big.Int) {
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+	a, b := big.NewInt(0), big.NewInt(1)
+	c, d := new(big.Int), new(big.Int)
+	for i := bits.Len64(uint64(n)) - 1; i >= 0; i-- {
+		// c = F(2k) = F(k)·(2F(k+1) - F(k)), d = F(2k+1) = F(k)² + F(k+1)²
+		c.Lsh(b, 1).Sub(c, a).Mul(c, a)
+		d.Mul(a, a)
+		b.Mul(b, b).Add(b, d)
+		if (n>>i)&1 == 1 {
+			a.Set(b)
+			b.Add(b, c)
+		} else {
+			a.Set(c)
+		}
+	}
+	if neg {
+		// F(-n) = (-1)^(n+1)·F(n) and F(-n+1) = (-1)^n·F(n-1)
+		b.Sub(b, a)
+		if n%2 == 0 {
+			a.Neg(a)
+		} else {
+			b.Neg(b)
+		}
+	}
+	return a, b
 }
@@ -0,0 +1,135 @@
+package eulerlib
+
+import (
+	"math"
+	"math/big"
+)
+
+// PisanoPeriod returns π(m), the period of the Fibonacci sequence modulo m.
+// For every prime power p^k dividing m the period is found among the divisors of
+// p-1 or 2(p+1) and then lifted to p^k, and the results are combined with Lcm.
+func PisanoPeriod(m int64) int64 {
+	if m < 1 {
+		panic("m must be positive")
+	}
+	res := int64(1)
+	ps, es := primePowers(m)
+	for i, p := range ps {
+		pk := Pow(p, int64(es[i]))
+		period := pisanoPrime(p)
+		// π(p^k) = π(p)·p^j for the smallest j <= k-1 that works
+		for FibMod(period, pk) != 0 || FibMod(period+1, pk) != 1 {
+			period *= p
+		}
+		res = Lcm(res, period)
+	}
+	return res
+}
+
+// pisanoPrime returns π(p) for a prime p
+func pisanoPrime(p int64) int64 {
+	switch p {
+	case 2:
+		return 3
+	case 5:
+		return 20
+	}
+	bound := 2 * (p + 1)
+	if p%10 == 1 || p%10 == 9 {
+		bound = p - 1
+	}
+	for _, d := range Divisors(bound) {
+		if FibMod(d, p) == 0 && FibMod(d+1, p) == 1 {
+			return d
+		}
+	}
+	return bound
+}
+
+// FibonacciEntryPoint returns α(m), the rank of apparition of m:
+// the smallest n > 0 such that m divides F(n).
+// Like PisanoPeriod it works per prime power and combines the results with Lcm.
+func FibonacciEntryPoint(m int64) int64 {
+	if m < 1 {
+		panic("m must be positive")
+	}
+	res := int64(1)
+	ps, es := primePowers(m)
+	for i, p := range ps {
+		pk := Pow(p, int64(es[i]))
+		rank := entryPointPrime(p)
+		// α(p^k) = α(p)·p^j for the smallest j <= k-1 that works
+		for FibMod(rank, pk) != 0 {
+			rank *= p
+		}
+		res = Lcm(res, rank)
+	}
+	return res
+}
+
+// entryPointPrime returns α(p) for a prime p, which divides p - (5/p)
+func entryPointPrime(p int64) int64 {
+	switch p {
+	case 2:
+		return 3
+	case 5:
+		return 5
+	}
+	bound := p + 1
+	if p%5 == 1 || p%5 == 4 {
+		bound = p - 1
+	}
+	for _, d := range Divisors(bound) {
+		if FibMod(d, p) == 0 {
+			return d
+		}
+	}
+	return bound
+}
+
+// IsFibonacci checks whether n is a Fibonacci number
+func IsFibonacci(n int64) bool {
+	_, ok := FibonacciIndex(n)
+	return ok
+}
+
+// FibonacciIndex returns the index k with F(k) == n and whether it exists.
+// For n == 1 the smallest index 1 is returned.
+func FibonacciIndex(n int64) (int, bool) {
+	for k, f := range FibonacciSeq(n) {
+		if f == n {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+// IsFibonacciBig checks whether the Big Integer n is a Fibonacci number
+func IsFibonacciBig(n *big.Int) bool {
+	_, ok := FibonacciIndexBig(n)
+	return ok
+}
+
+// FibonacciIndexBig returns the index k with F(k) == n and whether it exists.
+// The index is estimated from F(k) ≈ φ^k/√5 and verified with Fib.
+// For n == 1 the smallest index 1 is returned.
+func FibonacciIndexBig(n *big.Int) (int64, bool) {
+	if n.Sign() < 0 {
+		return 0, false
+	}
+	if n.IsInt64() {
+		k, ok := FibonacciIndex(n.Int64())
+		return int64(k), ok
+	}
+	// log2(n) from the leading 64 bits, so that n may exceed the float64 range
+	shift := n.BitLen() - 64
+	top := new(big.Int).Rsh(n, uint(shift)).Uint64()
+	log2n := float64(shift) + math.Log2(float64(top))
+	k := int64(math.Round((log2n + math.Log2(math.Sqrt(5))) / math.Log2(math.Phi)))
+	for i := k - 1; i <= k+1; i++ {
+		if Fib(i).Cmp(n) == 0 {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,84 @@
+package eulerlib
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestPisanoPeriod(t *testing.T) {
+	// OEIS A001175
+	want := []int64{1, 3, 8, 6, 20, 24, 16, 12, 24, 60, 10, 24, 28, 48, 40, 24, 36, 24, 18, 60, 16, 30, 48, 24, 100}
+	for i, w := range want {
+		m := int64(i + 1)
+		if got := PisanoPeriod(m); got != w {
+			t.Errorf("PisanoPeriod(%d) = %d, want %d", m, got, w)
+		}
+	}
+	// brute force comparison
+	for m := int64(2); m <= 2000; m++ {
+		a, b, period := int64(0), int64(1), int64(0)
+		for {
+			a, b = b, (a+b)%m
+			period++
+			if a == 0 && b == 1 {
+				break
+			}
+		}
+		if got := PisanoPeriod(m); got != period {
+			t.Fatalf("PisanoPeriod(%d) = %d, want %d", m, got, period)
+		}
+	}
+	if got := PisanoPeriod(1000000007); got != 2000000016 {
+		t.Errorf("PisanoPeriod(10^9+7) = %d, want 2000000016", got)
+	}
+}
+
+func TestFibonacciEntryPoint(t *testing.T) {
+	// OEIS A001177
+	want := []int64{1, 3, 4, 6, 5, 12, 8, 6, 12, 15, 10, 12, 7, 24, 20, 12, 9, 12, 18, 30}
+	for i, w := range want {
+		m := int64(i + 1)
+		if got := FibonacciEntryPoint(m); got != w {
+			t.Errorf("FibonacciEntryPoint(%d) = %d, want %d", m, got, w)
+		}
+	}
+	for m := int64(2); m <= 2000; m++ {
+		n := int64(1)
+		for FibMod(n, m) != 0 {
+			n++
+		}
+		if got := FibonacciEntryPoint(m); got != n {
+			t.Fatalf("FibonacciEntryPoint(%d) = %d, want %d", m, got, n)
+		}
+	}
+}
+
+func TestFibonacciIndex(t *testing.T) {
+	testNums := []int64{0, 1, 2, 3, 4, 5, 21, 22, 7540113804746346429, 7540113804746346428}
+	want := []int{0, 1, 3, 4, -1, 5, 8, -1, 92, -1}
+	for i, num := range testNums {
+		got, ok := FibonacciIndex(num)
+		if ok != (want[i] >= 0) || ok && got != want[i] {
+			t.Errorf("FibonacciIndex(%d) = %d, %t, want %d", num, got, ok, want[i])
+		}
+		if IsFibonacci(num) != ok {
+			t.Errorf("IsFibonacci(%d) = %t, want %t", num, !ok, ok)
+		}
+	}
+}
+
+func TestFibonacciIndexBig(t *testing.T) {
+	for _, k := range []int64{0, 3, 10, 92, 93, 100, 1000, 5000} {
+		f := Fib(k)
+		got, ok := FibonacciIndexBig(f)
+		if !ok || got != k {
+			t.Errorf("FibonacciIndexBig(F(%d)) = %d, %t, want %d", k, got, ok, k)
+		}
+		if k > 10 && IsFibonacciBig(new(big.Int).Add(f, big.NewInt(1))) {
+			t.Errorf("IsFibonacciBig(F(%d)+1) = true, want false", k)
+		}
+	}
+	if IsFibonacciBig(big.NewInt(-1)) {
+		t.Errorf("IsFibonacciBig(-1) = true, want false")
+	}
+}
@@ -1,7 +1,9 @@
 package eulerlib
 
 import (
+	"math"
 	"math/big"
+	"reflect"
 	"testing"
 )
 
@@ -32,6 +34,14 @@ func TestGenFibo(t *testing.T) {
 			t.Fatalf("GenFibo(3)[%d] = %d, want %d", i, got[i], want[i])
 		}
 	}
+	for _, limit := range []int64{-4, -5, -100} {
+		if got := collectInt64(GenFibo(limit)); len(got) != 0 {
+			t.Errorf("GenFibo(%d) = %v, want []", limit, got)
+		}
+	}
+	if got := collectInt64(GenFibo(-3)); len(got) != 1 || got[0] != 1 {
+		t.Errorf("GenFibo(-3) = %v, want [1]", got)
+	}
 }
 
 func TestFibonacci(t *testing.T) {
@@ -45,6 +55,11 @@ func TestFibonacci(t *testing.T) {
 			t.Fatalf("Fibonacci(0)[%d] = %d, want %d", i, got[i], want[i])
 		}
 	}
+	for _, limit := range []int64{-4, -5, -100} {
+		if got := Fibonacci(limit); len(got) != 0 {
+			t.Errorf("Fibonacci(%d) = %v, want []", limit, got)
+		}
+	}
 }
 
 func TestFibonacciSingle(t *testing.T) {
@@ -56,6 +71,9 @@ func TestFibonacciSingle(t *testing.T) {
 		{1, 1},
 		{2, 1},
 		{10, 55},
+		{92, 7540113804746346429},
+		// F(93) = 12200160415121876738 wraps around
+		{93, -6246583658587674878},
 	}
 
 	for _, tc := range testCases {
@@ -64,6 +82,13 @@ func TestFibonacciSingle(t *testing.T) {
 			t.Errorf("FibonacciSingle(%d) = %d, want %d", tc.n, got, tc.want)
 		}
 	}
+	mod := new(big.Int).Lsh(big.NewInt(1), 64)
+	for _, n := range []int64{94, 200, 1000} {
+		want := int64(new(big.Int).Mod(Fib(n), mod).Uint64())
+		if got := FibonacciSingle(n); got != want {
+			t.Errorf("FibonacciSingle(%d) = %d, want %d", n, got, want)
+		}
+	}
 }
 
 func TestGenFiboBig(t *testing.T) {
@@ -99,22 +124,114 @@ func TestFibonacciSingleBig(t *testing.T) {
 	}
 }
 
-func TestPowBigFloat(t *testing.T) {
-	base := big.NewFloat(3)
-	got := powBigFloat(base, 4)
-	f, _ := got.Float64()
-	if f != 81 {
-		t.Fatalf("powBigFloat(3, 4) = %v, want 81", f)
+func TestFib(t *testing.T) {
+	a, b := big.NewInt(0), big.NewInt(1)
+	for n := int64(0); n <= 1500; n++ {
+		if got := Fib(n); got.Cmp(a) != 0 {
+			t.Fatalf("Fib(%d) = %s, want %s", n, got, a)
+		}
+		a.Add(a, b)
+		a, b = b, a
+	}
+	negative := []int64{0, 1, -1, 2, -3, 5, -8, 13}
+	for n, want := range negative {
+		if got := Fib(int64(-n)); got.Int64() != want {
+			t.Errorf("Fib(%d) = %s, want %d", -n, got, want)
+		}
+	}
+	if got := FibonacciSingleBig(500); got.Cmp(Fib(500)) != 0 {
+		t.Errorf("FibonacciSingleBig(500) = %s, want %s", got, Fib(500))
+	}
+	if got := FibonacciSingle(92); got != 7540113804746346429 {
+		t.Errorf("FibonacciSingle(92) = %d, want 7540113804746346429", got)
+	}
+}
+
+func TestLucasNumber(t *testing.T) {
+	want := []int64{2, 1, 3, 4, 7, 11, 18, 29, 47, 76, 123}
+	for n, w := range want {
+		if got := LucasNumber(int64(n)); got.Int64() != w {
+			t.Errorf("LucasNumber(%d) = %s, want %d", n, got, w)
+		}
+		sign := int64(1)
+		if n%2 == 1 {
+			sign = -1
+		}
+		if got := LucasNumber(int64(-n)); got.Int64() != sign*w {
+			t.Errorf("LucasNumber(%d) = %s, want %d", -n, got, sign*w)
+		}
+	}
+	// L(n) = F(n-1) + F(n+1)
+	n := int64(1000)
+	want1000 := new(big.Int).Add(Fib(n-1), Fib(n+1))
+	if got := LucasNumber(n); got.Cmp(want1000) != 0 {
+		t.Errorf("LucasNumber(%d) = %s, want %s", n, got, want1000)
+	}
+}
+
+func TestFibMod(t *testing.T) {
+	moduli := []int64{1, 2, 10, 1000000007, 9223372036854775783}
+	for _, m := range moduli {
+		for n := int64(0); n <= 300; n++ {
+			want := new(big.Int).Mod(Fib(n), big.NewInt(m)).Int64()
+			if got := FibMod(n, m); got != want {
+				t.Fatalf("FibMod(%d, %d) = %d, want %d", n, m, got, want)
+			}
+		}
+	}
+	// the Pisano period of 10 is 60
+	if got := FibMod(math.MaxInt64, 10); got != FibMod(math.MaxInt64%60, 10) {
+		t.Errorf("FibMod(MaxInt64, 10) = %d, want %d", got, FibMod(math.MaxInt64%60, 10))
 	}
 }
 
-func TestZero(t *testing.T) {
-	z := zero()
-	if z.Prec() != 256 {
-		t.Fatalf("zero().Prec() = %d, want 256", z.Prec())
+func TestFibonacciUpTo(t *testing.T) {
+	got := FibonacciUpTo(10)
+	want := []int64{0, 1, 1, 2, 3, 5, 8}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FibonacciUpTo(10) = %v, want %v", got, want)
+	}
+	// Project Euler 2: the sum of the even Fibonacci numbers not exceeding four million
+	sum := int64(0)
+	for _, f := range FibonacciSeq(4000000) {
+		if f%2 == 0 {
+			sum += f
+		}
+	}
+	if sum != 4613732 {
+		t.Errorf("sum of even FibonacciSeq(4000000) = %d, want 4613732", sum)
 	}
-	f, _ := z.Float64()
-	if f != 0 {
-		t.Fatalf("zero() = %v, want 0", f)
+	all := FibonacciUpTo(math.MaxInt64)
+	if len(all) != 93 || all[92] != 7540113804746346429 {
+		t.Errorf("FibonacciUpTo(MaxInt64) has %d elements ending in %d, want 93 ending in 7540113804746346429", len(all), all[len(all)-1])
+	}
+}
+
+func TestFibonacciN(t *testing.T) {
+	got := FibonacciN(8)
+	want := []int64{0, 1, 1, 2, 3, 5, 8, 13}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FibonacciN(8) = %v, want %v", got, want)
+	}
+	if got := FibonacciN(0); len(got) != 0 {
+		t.Errorf("FibonacciN(0) = %v, want []", got)
+	}
+	for i, f := range FibonacciBigN(300) {
+		if f.Cmp(Fib(int64(i))) != 0 {
+			t.Fatalf("FibonacciBigN(300)[%d] = %s, want %s", i, f, Fib(int64(i)))
+		}
+	}
+}
+
+func TestFibonacciBigUpTo(t *testing.T) {
+	limit := new(big.Int).Lsh(big.NewInt(1), 100)
+	got := FibonacciBigUpTo(limit)
+	if len(got) != 146 {
+		t.Fatalf("FibonacciBigUpTo(2^100) has %d elements, want 146", len(got))
+	}
+	for i, f := range got {
+		if f.Cmp(Fib(int64(i))) != 0 {
+			t.Fatalf("FibonacciBigUpTo(2^100)[%d] = %s, want %s", i, f, Fib(int64(i)))
+		}
 	}
 }
@@ -0,0 +1,93 @@
+package eulerlib
+
+import "iter"
+
+// FloorBlocks returns an iterator over the O(sqrt(n)) blocks of i in [1, n] on which
+// floor(n/i) is constant. Each step yields the value floor(n/i) and the block [lo, hi].
+//
+// Example:
+// for q, b := range FloorBlocks(10) { ... }
+// // yields (10, [1 1]), (5, [2 2]), (3, [3 3]), (2, [4 5]), (1, [6 10])
+func FloorBlocks[E Integer](n E) iter.Seq2[E, [2]E] {
+	return func(yield func(E, [2]E) bool) {
+		for i := E(1); i > 0 && i <= n; {
+			q := n / i
+			j := n / q
+			if !yield(q, [2]E{i, j}) {
+				return
+			}
+			i = j + 1
+		}
+	}
+}
+
+// FloorBlocksPair returns an iterator over the blocks of i in [1, min(n, m)] on which
+// both floor(n/i) and floor(m/i) are constant. Each step yields the pair
+// [floor(n/i), floor(m/i)] and the block [lo, hi].
+func FloorBlocksPair[E Integer](n, m E) iter.Seq2[[2]E, [2]E] {
+	return func(yield func([2]E, [2]E) bool) {
+		for i := E(1); i > 0 && i <= n && i <= m; {
+			qn, qm := n/i, m/i
+			j := min(n/qn, m/qm)
+			if !yield([2]E{qn, qm}, [2]E{i, j}) {
+				return
+			}
+			i = j + 1
+		}
+	}
+}
+
+// FloorSum returns Σ_{i=0}^{n-1} floor((a·i + b) / m) in O(log m) time.
+// a and b may be negative, n must be >= 0 and m >= 1.
+// Like the AtCoder Library version, the result wraps around on int64 overflow.
+func FloorSum(n, m, a, b int64) int64 {
+	if n < 0 || m < 1 {
+		panic("FloorSum requires n >= 0 and m >= 1")
+	}
+	res := uint64(0)
+	if a < 0 {
+		a2 := a%m + m
+		if a2 == m {
+			a2 = 0
+		}
+		res -= uint64(n) * uint64(n-1) / 2 * uint64((a2-a)/m)
+		a = a2
+	}
+	if b < 0 {
+		b2 := b%m + m
+		if b2 == m {
+			b2 = 0
+		}
+		res -= uint64(n) * uint64((b2-b)/m)
+		b = b2
+	}
+	return int64(res + floorSumUnsigned(uint64(n), uint64(m), uint64(a), uint64(b)))
+}
+
+// floorDiv returns floor(a / b) for b > 0
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
+
+func floorSumUnsigned(n, m, a, b uint64) (res uint64) {
+	for {
+		if a >= m {
+			res += n * (n - 1) / 2 * (a / m)
+			a %= m
+		}
+		if b >= m {
+			res += n * (b / m)
+			b %= m
+		}
+		yMax := a*n + b
+		if yMax < m {
+			return
+		}
+		n, b = yMax/m, yMax%m
+		m, a = a, m
+	}
+}
@@ -0,0 +1,102 @@
+package eulerlib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFloorBlocks(t *testing.T) {
+	got := [][3]int{}
+	for q, b := range FloorBlocks(10) {
+		got = append(got, [3]int{q, b[0], b[1]})
+	}
+	want := [][3]int{{10, 1, 1}, {5, 2, 2}, {3, 3, 3}, {2, 4, 5}, {1, 6, 10}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FloorBlocks(10) == %v, want %v", got, want)
+	}
+
+	for n := int64(0); n <= 300; n++ {
+		next := int64(1)
+		for q, b := range FloorBlocks(n) {
+			if b[0] != next {
+				t.Fatalf("FloorBlocks(%d) skipped from %d to %d", n, next, b[0])
+			}
+			for i := b[0]; i <= b[1]; i++ {
+				if n/i != q {
+					t.Fatalf("FloorBlocks(%d) block %v has value %d, but %d/%d == %d", n, b, q, n, i, n/i)
+				}
+			}
+			next = b[1] + 1
+		}
+		if next != n+1 {
+			t.Fatalf("FloorBlocks(%d) ended at %d", n, next-1)
+		}
+	}
+
+	// Σ_{i<=n} floor(n/i) is the divisor summatory function
+	sum := int64(0)
+	for q, b := range FloorBlocks(int64(1000000)) {
+		sum += q * (b[1] - b[0] + 1)
+	}
+	if want := DivisorSummatory(1000000); sum != want {
+		t.Errorf("Σ floor(10^6/i) == %d, want %d", sum, want)
+	}
+
+	// the last block must not overflow the loop variable
+	count := 0
+	for range FloorBlocks(uint8(255)) {
+		count++
+	}
+	if count != 30 {
+		t.Errorf("FloorBlocks(uint8(255)) yielded %d blocks, want 30", count)
+	}
+}
+
+func TestFloorBlocksPair(t *testing.T) {
+	for n := 1; n <= 60; n++ {
+		for m := 1; m <= 60; m++ {
+			next := 1
+			for q, b := range FloorBlocksPair(n, m) {
+				if b[0] != next {
+					t.Fatalf("FloorBlocksPair(%d, %d) skipped from %d to %d", n, m, next, b[0])
+				}
+				for i := b[0]; i <= b[1]; i++ {
+					if n/i != q[0] || m/i != q[1] {
+						t.Fatalf("FloorBlocksPair(%d, %d) block %v has values %v", n, m, b, q)
+					}
+				}
+				next = b[1] + 1
+			}
+			if next != min(n, m)+1 {
+				t.Fatalf("FloorBlocksPair(%d, %d) ended at %d", n, m, next-1)
+			}
+		}
+	}
+}
+
+func TestFloorDiv(t *testing.T) {
+	tests := [][3]int64{{7, 2, 3}, {-7, 2, -4}, {-8, 2, -4}, {0, 5, 0}, {-1, 5, -1}}
+	for _, tt := range tests {
+		if got := floorDiv(tt[0], tt[1]); got != tt[2] {
+			t.Errorf("floorDiv(%d, %d) == %d, want %d", tt[0], tt[1], got, tt[2])
+		}
+	}
+}
+
+func TestFloorSum(t *testing.T) {
+	for n := int64(0); n <= 20; n++ {
+		for m := int64(1); m <= 20; m++ {
+			for a := int64(-20); a <= 20; a += 3 {
+				for b := int64(-20); b <= 20; b += 3 {
+					want := int64(0)
+					for i := int64(0); i < n; i++ {
+						want += floorDiv(a*i+b, m)
+					}
+					if got := FloorSum(n, m, a, b); got != want {
+						t.Errorf("FloorSum(%d, %d, %d, %d) == %d, want %d", n, m, a, b, got, want)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package eulerlib
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// The combinatorial iterators of this package yield a new slice for every item, so the items
+// can be kept or collected with slices.Collect. Those that are often run over huge counts,
+// such as the generators in this file, also come in a Reuse variant that yields one buffer,
+// overwritten at every step, and so does not allocate per item. A slice from a Reuse variant
+// is only valid until the next iteration step.
+
+// clones returns an iterator that yields a copy of every slice of seq
+func clones[E any](seq iter.Seq[[]E]) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		for s := range seq {
+			if !yield(slices.Clone(s)) {
+				return
+			}
+		}
+	}
+}
+
+// PowerSet returns an iterator over all 2^len(set) subsets of set in Gray-code order,
+// so each subset differs from the previous one by exactly one element. The elements of
+// a subset keep their order in set. It panics if set has 64 or more elements.
+//
+// Example:
+// for s := range PowerSet([]int{1, 2, 3}) { ... }
+// // yields [], [1], [1 2], [2], [2 3], [1 2 3], [1 3], [3]
+func PowerSet[E any](set []E) iter.Seq[[]E] {
+	return clones(PowerSetReuse(set))
+}
+
+// PowerSetReuse is like PowerSet, but yields the same buffer at every step,
+// which is only valid until the next one
+func PowerSetReuse[E any](set []E) iter.Seq[[]E] {
+	if len(set) >= 64 {
+		panic("PowerSet needs fewer than 64 elements")
+	}
+	return func(yield func([]E) bool) {
+		buf := make([]E, 0, len(set))
+		for i := uint64(0); i < 1<<len(set); i++ {
+			buf = buf[:0]
+			for g := i ^ i>>1; g != 0; g &= g - 1 {
+				buf = append(buf, set[bits.TrailingZeros64(g)])
+			}
+			if !yield(buf) {
+				return
+			}
+		}
+	}
+}
+
+// Product returns an iterator over the Cartesian product of the given slices in
+// lexicographic order, the last slice varying fastest. It yields one empty tuple for
+// no slices and nothing if any slice is empty.
+//
+// Example:
+// for t := range Product([]int{1, 2}, []int{3, 4}) { ... }
+// // yields [1 3], [1 4], [2 3], [2 4]
+func Product[E any](sets ...[]E) iter.Seq[[]E] {
+	return clones(ProductReuse(sets...))
+}
+
+// ProductReuse is like Product, but yields the same buffer at every step,
+// which is only valid until the next one
+func ProductReuse[E any](sets ...[]E) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		for _, s := range sets {
+			if len(s) == 0 {
+				return
+			}
+		}
+		idx := make([]int, len(sets))
+		buf := make([]E, len(sets))
+		for i, s := range sets {
+			buf[i] = s[0]
+		}
+		for yield(buf) {
+			// advance the odometer, resetting every position that wraps around
+			i := len(sets) - 1
+			for ; i >= 0 && idx[i] == len(sets[i])-1; i-- {
+				idx[i] = 0
+				buf[i] = sets[i][0]
+			}
+			if i < 0 {
+				return
+			}
+			idx[i]++
+			buf[i] = sets[i][idx[i]]
+		}
+	}
+}
+
+// Tuples returns an iterator over all len(alphabet)^k tuples of length k over alphabet,
+// in lexicographic order of the element positions. This is the Cartesian product of
+// k copies of alphabet.
+//
+// Example:
+// for t := range Tuples([]byte("ab"), 2) { ... }
+// // yields "aa", "ab", "ba", "bb"
+func Tuples[E any](alphabet []E, k int) iter.Seq[[]E] {
+	return clones(TuplesReuse(alphabet, k))
+}
+
+// TuplesReuse is like Tuples, but yields the same buffer at every step,
+// which is only valid until the next one
+func TuplesReuse[E any](alphabet []E, k int) iter.Seq[[]E] {
+	if k < 0 {
+		panic("k must not be negative")
+	}
+	sets := make([][]E, k)
+	for i := range sets {
+		sets[i] = alphabet
+	}
+	return ProductReuse(sets...)
+}
+
+// KPermutations returns an iterator over all n!/(n-k)! ordered arrangements of k elements
+// of set, in lexicographic order of the element positions. It yields nothing if k is
+// negative or larger than len(set).
+//
+// Example:
+// for p := range KPermutations([]int{1, 2, 3}, 2) { ... }
+// // yields [1 2], [1 3], [2 1], [2 3], [3 1], [3 2]
+func KPermutations[E any](set []E, k int) iter.Seq[[]E] {
+	return clones(KPermutationsReuse(set, k))
+}
+
+// KPermutationsReuse is like KPermutations, but yields the same buffer at every step,
+// which is only valid until the next one
+func KPermutationsReuse[E any](set []E, k int) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		if k < 0 || k > len(set) {
+			return
+		}
+		buf := make([]E, k)
+		used := make([]bool, len(set))
+		// fill returns false once the consumer stops the iteration
+		var fill func(pos int) bool
+		fill = func(pos int) bool {
+			if pos == k {
+				return yield(buf)
+			}
+			for i, e := range set {
+				if used[i] {
+					continue
+				}
+				used[i] = true
+				buf[pos] = e
+				ok := fill(pos + 1)
+				used[i] = false
+				if !ok {
+					return false
+				}
+			}
+			return true
+		}
+		fill(0)
+	}
+}
@@ -0,0 +1,122 @@
+package eulerlib
+
+import (
+	"iter"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestPowerSet(t *testing.T) {
+	got := slices.Collect(PowerSet([]int{1, 2, 3}))
+	want := [][]int{{}, {1}, {1, 2}, {2}, {2, 3}, {1, 2, 3}, {1, 3}, {3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PowerSet(1..3) == %v, want %v", got, want)
+	}
+
+	// consecutive subsets differ by one element and every subset appears once
+	seen := map[string]bool{}
+	prev := -1
+	for s := range PowerSet(Range(0, 10)) {
+		if prev >= 0 && len(s)-prev != 1 && prev-len(s) != 1 {
+			t.Fatalf("PowerSet(0..9) yielded %v after a subset of length %d", s, prev)
+		}
+		prev = len(s)
+		seen[JoinSlice(s)] = true
+	}
+	if len(seen) != 1024 {
+		t.Errorf("PowerSet(0..9) yielded %d distinct subsets, want %d", len(seen), 1024)
+	}
+}
+
+func TestProduct(t *testing.T) {
+	got := slices.Collect(Product([]int{1, 2}, []int{3}, []int{4, 5, 6}))
+	want := [][]int{{1, 3, 4}, {1, 3, 5}, {1, 3, 6}, {2, 3, 4}, {2, 3, 5}, {2, 3, 6}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Product(12, 3, 456) == %v, want %v", got, want)
+	}
+
+	if got := slices.Collect(Product[int]()); !reflect.DeepEqual(got, [][]int{{}}) {
+		t.Errorf("Product() == %v, want [[]]", got)
+	}
+	for p := range Product([]int{1, 2}, []int{}) {
+		t.Errorf("Product(12, empty) yielded %v, want nothing", p)
+	}
+
+}
+
+func TestTuples(t *testing.T) {
+	got := []string{}
+	for s := range Tuples([]byte("ab"), 3) {
+		got = append(got, string(s))
+	}
+	want := []string{"aaa", "aab", "aba", "abb", "baa", "bab", "bba", "bbb"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tuples(ab, 3) == %v, want %v", got, want)
+	}
+
+	count := 0
+	for range Tuples(Range(0, 10), 5) {
+		count++
+	}
+	if count != 100000 {
+		t.Errorf("Tuples(0..9, 5) yielded %d, want %d", count, 100000)
+	}
+}
+
+func TestKPermutations(t *testing.T) {
+	got := slices.Collect(KPermutations([]int{1, 2, 3}, 2))
+	want := [][]int{{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("KPermutations(1..3, 2) == %v, want %v", got, want)
+	}
+
+	// with k = n they match the lexicographic permutations
+	all := slices.Collect(KPermutations([]int{1, 2, 3, 4}, 4))
+	if lex := slices.Collect(LexPermutations([]int{1, 2, 3, 4})); !reflect.DeepEqual(all, lex) {
+		t.Errorf("KPermutations(1..4, 4) == %v, want %v", all, lex)
+	}
+
+	count := 0
+	for range KPermutations(Range(0, 10), 4) {
+		count++
+	}
+	if count != 5040 {
+		t.Errorf("KPermutations(0..9, 4) yielded %d, want %d", count, 5040)
+	}
+	for p := range KPermutations([]int{1, 2}, 3) {
+		t.Errorf("KPermutations(12, 3) yielded %v, want nothing", p)
+	}
+}
+
+func TestReuseGenerators(t *testing.T) {
+	set := []int{1, 2, 3}
+	tests := []struct {
+		name         string
+		fresh, reuse iter.Seq[[]int]
+	}{
+		{"PowerSet", PowerSet(set), PowerSetReuse(set)},
+		{"Product", Product(set, set), ProductReuse(set, set)},
+		{"Tuples", Tuples(set, 3), TuplesReuse(set, 3)},
+		{"KPermutations", KPermutations(set, 2), KPermutationsReuse(set, 2)},
+	}
+	for _, tt := range tests {
+		want := slices.Collect(tt.fresh)
+		i := 0
+		var first []int
+		for s := range tt.reuse {
+			if !slices.Equal(s, want[i]) {
+				t.Errorf("%sReuse yielded %v at %d, want %v", tt.name, s, i, want[i])
+			}
+			if first == nil {
+				first = s
+			} else if len(s) > 0 && &first[:1][0] != &s[:1][0] {
+				t.Errorf("%sReuse yielded a new slice at %d", tt.name, i)
+			}
+			i++
+		}
+		if i != len(want) {
+			t.Errorf("%sReuse yielded %d items, want %d", tt.name, i, len(want))
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package eulerlib
+
+import (
+	"iter"
+	"math/big"
+)
+
+// LinearRecurrence describes a sequence a(n) = c[0]·a(n-1) + c[1]·a(n-2) + ... + c[d-1]·a(n-d)
+// of order d, given its coefficients c and its initial terms a(0), ..., a(d-1).
+type LinearRecurrence struct {
+	Coeffs  []int64
+	Initial []int64
+}
+
+// NewLinearRecurrence returns the recurrence with the given coefficients and initial terms,
+// which must have the same length
+//
+// Example:
+// tribonacci := NewLinearRecurrence([]int64{1, 1, 1}, []int64{0, 0, 1})
+func NewLinearRecurrence(coeffs, initial []int64) *LinearRecurrence {
+	if len(coeffs) != len(initial) {
+		panic("the number of coefficients and initial terms must match")
+	}
+	if len(coeffs) == 0 {
+		panic("a linear recurrence needs at least one coefficient")
+	}
+	return &LinearRecurrence{Coeffs: coeffs, Initial: initial}
+}
+
+// Order returns the order d of the recurrence
+func (r *LinearRecurrence) Order() int {
+	return len(r.Coeffs)
+}
+
+// Term returns a(n) exactly, using Kitamasa's method: x^n is reduced modulo the
+// characteristic polynomial in O(d² log n) Big Integer operations, and the
+// remainder's coefficients weight the initial terms.
+func (r *LinearRecurrence) Term(n int64) *big.Int {
+	return linearTerm(bigInts(r.Coeffs), bigInts(r.Initial), n)
+}
+
+// TermMod returns a(n) mod m with Kitamasa's method in O(d² log n) time
+func (r *LinearRecurrence) TermMod(n, m int64) int64 {
+	if n < 0 || m < 1 {
+		panic("TermMod requires n >= 0 and m >= 1")
+	}
+	coeffs := make([]int64, len(r.Coeffs))
+	for i, c := range r.Coeffs {
+		coeffs[i] = normMod(c, m)
+	}
+	add := func(a, b int64) int64 { return int64((uint64(a) + uint64(b)) % uint64(m)) }
+	mul := func(a, b int64) int64 { return mulMod(a, b, m) }
+	rem := kitamasa(coeffs, uint64(n), 0, 1%m, add, mul)
+	res := int64(0)
+	for i, v := range rem {
+		res = add(res, mul(v, normMod(r.Initial[i], m)))
+	}
+	return res
+}
+
+// TermMatrixMod returns a(n) mod m by raising the d×d companion matrix to the
+// n-th power with PowMonoid, in O(d³ log n) time. TermMod is faster for large d.
+func (r *LinearRecurrence) TermMatrixMod(n, m int64) int64 {
+	if n < 0 || m < 1 {
+		panic("TermMatrixMod requires n >= 0 and m >= 1")
+	}
+	d := r.Order()
+	// the companion matrix maps [a(k+d-1), ..., a(k)] to [a(k+d), ..., a(k+1)]
+	companion := newMatrix(d)
+	identity := newMatrix(d)
+	for i := range d {
+		companion[0][i] = normMod(r.Coeffs[i], m)
+		if i > 0 {
+			companion[i][i-1] = 1 % m
+		}
+		identity[i][i] = 1 % m
+	}
+	mul := func(a, b [][]int64) [][]int64 {
+		res := newMatrix(d)
+		for i := range d {
+			for k := range d {
+				if a[i][k] == 0 {
+					continue
+				}
+				for j := range d {
+					res[i][j] = int64((uint64(res[i][j]) + uint64(mulMod(a[i][k], b[k][j], m))) % uint64(m))
+				}
+			}
+		}
+		return res
+	}
+	p := PowMonoid(companion, uint64(n), identity, mul)
+	res := int64(0)
+	for j := range d {
+		res = int64((uint64(res) + uint64(mulMod(p[d-1][j], normMod(r.Initial[d-1-j], m), m))) % uint64(m))
+	}
+	return res
+}
+
+// Seq returns an infinite iterator over the exact terms a(0), a(1), ...
+func (r *LinearRecurrence) Seq() iter.Seq[*big.Int] {
+	return func(yield func(*big.Int) bool) {
+		d := r.Order()
+		window := make([]*big.Int, d)
+		for i, v := range r.Initial {
+			window[i] = big.NewInt(v)
+			if !yield(new(big.Int).Set(window[i])) {
+				return
+			}
+		}
+		for {
+			next := new(big.Int)
+			for i, c := range r.Coeffs {
+				next.Add(next, new(big.Int).Mul(big.NewInt(c), window[d-1-i]))
+			}
+			window = append(window[1:], next)
+			if !yield(new(big.Int).Set(next)) {
+				return
+			}
+		}
+	}
+}
+
+// SeqMod returns an infinite iterator over the terms a(0), a(1), ... modulo m
+func (r *LinearRecurrence) SeqMod(m int64) iter.Seq[int64] {
+	return func(yield func(int64) bool) {
+		d := r.Order()
+		window := make([]int64, d)
+		for i, v := range r.Initial {
+			window[i] = normMod(v, m)
+			if !yield(window[i]) {
+				return
+			}
+		}
+		for {
+			next := int64(0)
+			for i, c := range r.Coeffs {
+				next = int64((uint64(next) + uint64(mulMod(normMod(c, m), window[d-1-i], m))) % uint64(m))
+			}
+			window = append(window[1:], next)
+			if !yield(next) {
+				return
+			}
+		}
+	}
+}
+
+// Sum returns a(0) + ... + a(n-1) exactly.
+// The partial sums satisfy a recurrence of order d+1, whose characteristic
+// polynomial is that of a multiplied by (x - 1), so Sum is Term on that recurrence.
+func (r *LinearRecurrence) Sum(n int64) *big.Int {
+	coeffs, initial := r.partialSums()
+	return linearTerm(coeffs, initial, n)
+}
+
+// SumMod returns a(0) + ... + a(n-1) modulo m, see Sum
+func (r *LinearRecurrence) SumMod(n, m int64) int64 {
+	if m < 1 {
+		panic("SumMod requires m >= 1")
+	}
+	coeffs, initial := r.partialSums()
+	reduced := &LinearRecurrence{Coeffs: make([]int64, len(coeffs)), Initial: make([]int64, len(initial))}
+	bigM := big.NewInt(m)
+	for i := range coeffs {
+		reduced.Coeffs[i] = new(big.Int).Mod(coeffs[i], bigM).Int64()
+		reduced.Initial[i] = new(big.Int).Mod(initial[i], bigM).Int64()
+	}
+	return reduced.TermMod(n, m)
+}
+
+// partialSums returns the coefficients and initial terms of the recurrence of
+// S(n) = a(0) + ... + a(n-1) as Big Integers, since they can exceed int64
+func (r *LinearRecurrence) partialSums() (coeffs, initial []*big.Int) {
+	d := r.Order()
+	c := bigInts(r.Coeffs)
+	// (1 - c[0]x - ... - c[d-1]x^d)(1 - x) = 1 - (c[0]+1)x - (c[1]-c[0])x² - ... + c[d-1]x^(d+1)
+	coeffs = make([]*big.Int, d+1)
+	coeffs[0] = new(big.Int).Add(c[0], big.NewInt(1))
+	for i := 1; i < d; i++ {
+		coeffs[i] = new(big.Int).Sub(c[i], c[i-1])
+	}
+	coeffs[d] = new(big.Int).Neg(c[d-1])
+	initial = make([]*big.Int, d+1)
+	initial[0] = new(big.Int)
+	for i := 1; i <= d; i++ {
+		initial[i] = new(big.Int).Add(initial[i-1], big.NewInt(r.Initial[i-1]))
+	}
+	return
+}
+
+// linearTerm returns a(n) of the recurrence with the given coefficients and initial terms,
+// using Kitamasa's method
+func linearTerm(coeffs, initial []*big.Int, n int64) *big.Int {
+	if n < 0 {
+		panic("n must be non-negative")
+	}
+	add := func(a, b *big.Int) *big.Int { return new(big.Int).Add(a, b) }
+	mul := func(a, b *big.Int) *big.Int { return new(big.Int).Mul(a, b) }
+	rem := kitamasa(coeffs, uint64(n), big.NewInt(0), big.NewInt(1), add, mul)
+	res := new(big.Int)
+	for i, v := range rem {
+		res.Add(res, new(big.Int).Mul(v, initial[i]))
+	}
+	return res
+}
+
+// bigInts converts a slice of int64 to Big Integers
+func bigInts(s []int64) []*big.Int {
+	res := make([]*big.Int, len(s))
+	for i, v := range s {
+		res[i] = big.NewInt(v)
+	}
+	return res
+}
+
+// kitamasa returns the coefficients of x^n modulo the characteristic polynomial
+// x^d - c[0]x^(d-1) - ... - c[d-1], so that a(n) = Σ rem[i]·a(i)
+func kitamasa[T any](c []T, n uint64, zero, one T, add, mul func(T, T) T) []T {
+	d := len(c)
+	// reduce folds x^k for k >= d back using x^d = c[0]x^(d-1) + ... + c[d-1]
+	reduce := func(p []T) []T {
+		for k := len(p) - 1; k >= d; k-- {
+			for j := range d {
+				p[k-1-j] = add(p[k-1-j], mul(p[k], c[j]))
+			}
+		}
+		return p[:d]
+	}
+	poly := func(deg int) []T {
+		p := make([]T, deg)
+		for i := range p {
+			p[i] = zero
+		}
+		return p
+	}
+	mulmod := func(a, b []T) []T {
+		p := poly(2*d - 1)
+		for i := range d {
+			for j := range d {
+				p[i+j] = add(p[i+j], mul(a[i], b[j]))
+			}
+		}
+		return reduce(p)
+	}
+	identity := poly(max(d, 2))
+	identity[0] = one
+	x := poly(max(d, 2))
+	x[1] = one
+	return PowMonoid(reduce(x), n, reduce(identity), mulmod)
+}
+
+// newMatrix returns a d×d matrix of zeros
+func newMatrix(d int) [][]int64 {
+	res := make([][]int64, d)
+	for i := range res {
+		res[i] = make([]int64, d)
+	}
+	return res
+}
+
+// normMod returns a mod m in the range [0, m)
+func normMod(a, m int64) int64 {
+	a %= m
+	if a < 0 {
+		a += m
+	}
+	return a
+}
+
+// BerlekampMassey returns the coefficients of the shortest linear recurrence
+// that generates the given terms modulo the prime p, in the convention of
+// LinearRecurrence: a(n) = c[0]·a(n-1) + ... + c[d-1]·a(n-d) mod p.
+// 2d terms are enough to recover a recurrence of order d. It takes O(len(terms)²) time.
+func BerlekampMassey(terms []int64, p int64) []int64 {
+	// cur is the connection polynomial 1 + C[1]x + ... and prev the one before the last length change
+	cur, prev := []int64{1}, []int64{1}
+	length, shift, lastDisc := 0, 1, int64(1)
+	for n := range terms {
+		disc := normMod(terms[n], p)
+		for i := 1; i <= length; i++ {
+			disc = (disc + mulMod(cur[i], normMod(terms[n-i], p), p)) % p
+		}
+		if disc == 0 {
+			shift++
+			continue
+		}
+		coef := mulMod(disc, invMod(lastDisc, p), p)
+		next := append([]int64{}, cur...)
+		for len(next) < len(prev)+shift {
+			next = append(next, 0)
+		}
+		for i, v := range prev {
+			next[i+shift] = normMod(next[i+shift]-mulMod(coef, v, p), p)
+		}
+		if 2*length <= n {
+			prev, length, lastDisc, shift = cur, n+1-length, disc, 1
+		} else {
+			shift++
+		}
+		cur = next
+	}
+	res := make([]int64, length)
+	for i := range res {
+		if i+1 < len(cur) {
+			res[i] = normMod(-cur[i+1], p)
+		}
+	}
+	return res
+}
+
+// Extrapolate returns the n-th term (counting from 0) of the sequence that starts with
+// the given terms, modulo the prime p. The recurrence is found with BerlekampMassey
+// and evaluated with Kitamasa's method, so n may be as large as the int64 range.
+//
+// Example:
+// x := Extrapolate([]int64{0, 1, 1, 2, 3, 5, 8, 13}, 1000000000000000000, 1000000007)
+// // x == FibMod(1000000000000000000, 1000000007)
+func Extrapolate(terms []int64, n int64, p int64) int64 {
+	if n < 0 {
+		panic("n must be non-negative")
+	}
+	if n < int64(len(terms)) {
+		return normMod(terms[n], p)
+	}
+	coeffs := BerlekampMassey(terms, p)
+	if len(coeffs) == 0 {
+		return 0
+	}
+	return NewLinearRecurrence(coeffs, terms[:len(coeffs)]).TermMod(n, p)
+}
+
+// invMod returns the inverse of a modulo m, computed with the extended Euclidean algorithm.
+// It panics if a and m are not coprime.
+func invMod(a, m int64) int64 {
+	oldR, r := normMod(a, m), m
+	oldS, s := int64(1), int64(0)
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldS, s = s, oldS-q*s
+	}
+	if oldR != 1 {
+		panic("a has no inverse modulo m")
+	}
+	return normMod(oldS, m)
+}
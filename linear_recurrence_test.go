@@ -0,0 +1,201 @@
+package eulerlib
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestLinearRecurrenceTerm(t *testing.T) {
+	fib := NewLinearRecurrence([]int64{1, 1}, []int64{0, 1})
+	for _, n := range []int64{0, 1, 2, 10, 93, 1000} {
+		if got := fib.Term(n); got.Cmp(Fib(n)) != 0 {
+			t.Errorf("fib.Term(%d) = %s, want %s", n, got, Fib(n))
+		}
+	}
+	m := int64(1000000007)
+	n := int64(1000000000000000000)
+	if got, want := fib.TermMod(n, m), FibMod(n, m); got != want {
+		t.Errorf("fib.TermMod(%d, %d) = %d, want %d", n, m, got, want)
+	}
+	if got, want := fib.TermMatrixMod(n, m), FibMod(n, m); got != want {
+		t.Errorf("fib.TermMatrixMod(%d, %d) = %d, want %d", n, m, got, want)
+	}
+
+	// Pell numbers (OEIS A000129), tribonacci (A000073) and a geometric sequence of order 1
+	testCases := []struct {
+		r    *LinearRecurrence
+		want []int64
+	}{
+		{NewLinearRecurrence([]int64{2, 1}, []int64{0, 1}), []int64{0, 1, 2, 5, 12, 29, 70, 169, 408, 985, 2378}},
+		{NewLinearRecurrence([]int64{1, 1, 1}, []int64{0, 0, 1}), []int64{0, 0, 1, 1, 2, 4, 7, 13, 24, 44, 81, 149}},
+		{NewLinearRecurrence([]int64{3}, []int64{2}), []int64{2, 6, 18, 54, 162, 486}},
+		{NewLinearRecurrence([]int64{0, -1}, []int64{1, 0}), []int64{1, 0, -1, 0, 1, 0, -1}},
+	}
+	for _, tc := range testCases {
+		i := 0
+		for v := range tc.r.Seq() {
+			if i == len(tc.want) {
+				break
+			}
+			if v.Int64() != tc.want[i] {
+				t.Errorf("%v.Seq()[%d] = %s, want %d", tc.r.Coeffs, i, v, tc.want[i])
+			}
+			i++
+		}
+		for n, w := range tc.want {
+			if got := tc.r.Term(int64(n)); got.Int64() != w {
+				t.Errorf("%v.Term(%d) = %s, want %d", tc.r.Coeffs, n, got, w)
+			}
+			wm := normMod(w, 7)
+			if got := tc.r.TermMod(int64(n), 7); got != wm {
+				t.Errorf("%v.TermMod(%d, 7) = %d, want %d", tc.r.Coeffs, n, got, wm)
+			}
+			if got := tc.r.TermMatrixMod(int64(n), 7); got != wm {
+				t.Errorf("%v.TermMatrixMod(%d, 7) = %d, want %d", tc.r.Coeffs, n, got, wm)
+			}
+		}
+	}
+}
+
+func TestLinearRecurrenceSeqMod(t *testing.T) {
+	r := NewLinearRecurrence([]int64{1, 1, 1}, []int64{0, 0, 1})
+	n := int64(0)
+	for v := range r.SeqMod(1000) {
+		if want := r.TermMod(n, 1000); v != want {
+			t.Fatalf("SeqMod(1000)[%d] = %d, want %d", n, v, want)
+		}
+		n++
+		if n == 200 {
+			break
+		}
+	}
+}
+
+func TestLinearRecurrenceSum(t *testing.T) {
+	// Σ_{k<n} F(k) = F(n+1) - 1
+	fib := NewLinearRecurrence([]int64{1, 1}, []int64{0, 1})
+	for _, n := range []int64{0, 1, 2, 3, 10, 500} {
+		want := new(big.Int).Sub(Fib(n+1), big.NewInt(1))
+		if got := fib.Sum(n); got.Cmp(want) != 0 {
+			t.Errorf("fib.Sum(%d) = %s, want %s", n, got, want)
+		}
+	}
+	m := int64(998244353)
+	n := int64(123456789012345)
+	if got, want := fib.SumMod(n, m), normMod(FibMod(n+1, m)-1, m); got != want {
+		t.Errorf("fib.SumMod(%d, %d) = %d, want %d", n, m, got, want)
+	}
+	tri := NewLinearRecurrence([]int64{1, 1, 1}, []int64{0, 0, 1})
+	sum := big.NewInt(0)
+	for n := int64(0); n <= 60; n++ {
+		if got := tri.Sum(n); got.Cmp(sum) != 0 {
+			t.Errorf("tri.Sum(%d) = %s, want %s", n, got, sum)
+		}
+		sum.Add(sum, tri.Term(n))
+	}
+
+	// the partial sums of these overflow int64 in their initial terms or coefficients
+	huge := NewLinearRecurrence([]int64{1, 0}, []int64{1 << 62, 1 << 62})
+	if got, want := huge.Sum(4), new(big.Int).Lsh(big.NewInt(1), 64); got.Cmp(want) != 0 {
+		t.Errorf("huge.Sum(4) = %s, want %s", got, want)
+	}
+	if got, want := huge.SumMod(4, 1000000007), new(big.Int).Mod(huge.Sum(4), big.NewInt(1000000007)).Int64(); got != want {
+		t.Errorf("huge.SumMod(4, 1000000007) = %d, want %d", got, want)
+	}
+	maxCoeff := NewLinearRecurrence([]int64{math.MaxInt64}, []int64{1})
+	want := big.NewInt(1)
+	for _, c := range []int64{math.MaxInt64, math.MaxInt64} {
+		want.Mul(want, big.NewInt(c)).Add(want, big.NewInt(1))
+	}
+	if got := maxCoeff.Sum(3); got.Cmp(want) != 0 {
+		t.Errorf("maxCoeff.Sum(3) = %s, want %s", got, want)
+	}
+}
+
+func TestBerlekampMassey(t *testing.T) {
+	p := int64(1000000007)
+	testCases := []struct {
+		terms []int64
+		want  []int64
+	}{
+		{[]int64{0, 1, 1, 2, 3, 5, 8, 13, 21, 34}, []int64{1, 1}},
+		{[]int64{0, 0, 1, 1, 2, 4, 7, 13, 24, 44, 81, 149}, []int64{1, 1, 1}},
+		{[]int64{1, 2, 4, 8, 16, 32}, []int64{2}},
+		{[]int64{0, 0, 0, 0}, []int64{}},
+		{[]int64{1, 0, -1, 0, 1, 0, -1, 0}, []int64{0, p - 1}},
+	}
+	for _, tc := range testCases {
+		got := BerlekampMassey(tc.terms, p)
+		if len(got) != len(tc.want) {
+			t.Errorf("BerlekampMassey(%v) = %v, want %v", tc.terms, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("BerlekampMassey(%v) = %v, want %v", tc.terms, got, tc.want)
+				break
+			}
+		}
+	}
+
+	// recover a random-looking recurrence of order 5 from 10 terms
+	r := NewLinearRecurrence([]int64{3, 141, 59, 26, 535}, []int64{8, 97, 93, 23, 84})
+	terms := []int64{}
+	for v := range r.SeqMod(p) {
+		terms = append(terms, v)
+		if len(terms) == 10 {
+			break
+		}
+	}
+	got := BerlekampMassey(terms, p)
+	for i, c := range r.Coeffs {
+		if i >= len(got) || got[i] != c {
+			t.Fatalf("BerlekampMassey(%v) = %v, want %v", terms, got, r.Coeffs)
+		}
+	}
+}
+
+func TestExtrapolate(t *testing.T) {
+	p := int64(1000000007)
+	n := int64(1000000000000000000)
+	fib := []int64{0, 1, 1, 2, 3, 5, 8, 13}
+	if got, want := Extrapolate(fib, n, p), FibMod(n, p); got != want {
+		t.Errorf("Extrapolate(fib, %d, %d) = %d, want %d", n, p, got, want)
+	}
+	if got := Extrapolate(fib, 5, p); got != 5 {
+		t.Errorf("Extrapolate(fib, 5, %d) = %d, want 5", p, got)
+	}
+	// Σ_{k<=n} k² is a polynomial of degree 3, so it satisfies a recurrence of order 4
+	squares := []int64{}
+	sum := int64(0)
+	for k := int64(0); k < 10; k++ {
+		sum += k * k
+		squares = append(squares, sum)
+	}
+	m := int64(1000000)
+	want := m * (m + 1) % p * (2*m + 1) % p * invMod(6, p) % p
+	if got := Extrapolate(squares, m, p); got != want {
+		t.Errorf("Extrapolate(square sums, %d, %d) = %d, want %d", m, p, got, want)
+	}
+
+	defer func() {
+		if r := recover(); r != "n must be non-negative" {
+			t.Errorf("Extrapolate(fib, -1, %d) panicked with %v, want n must be non-negative", p, r)
+		}
+	}()
+	Extrapolate(fib, -1, p)
+}
+
+func TestInvMod(t *testing.T) {
+	for _, m := range []int64{7, 1000000007, 36} {
+		for a := int64(1); a < 30; a++ {
+			if gcd(a, m) != 1 {
+				continue
+			}
+			if got := invMod(a, m); mulMod(a, got, m) != 1 {
+				t.Errorf("invMod(%d, %d) = %d, which is not an inverse", a, m, got)
+			}
+		}
+	}
+}
@@ -3,70 +3,30 @@ package eulerlib
 import (
 	"math"
 	"math/big"
-	"math/bits"
 	"strconv"
 )
 
-// returns the number of divisors the given integer has
-func CountDivisors[E Integer](n E) E {
-	count := E(0)
-	end := E(math.Sqrt(float64(n)))
-	for i := E(1); i < end; i++ {
-		if n%i == 0 {
-			count += 2
-		}
-	}
-	if end*end == n {
-		count++
-	}
-	return count
-}
-
-// returns all divisors of the given integer
-func Divisors[E Integer](n E) []E {
-	end := E(math.Sqrt(float64(n)))
-	divisors := []E{1}
-
-	for i := E(2); i <= end; i++ {
-		if n%i == 0 {
-			divisors = append(divisors, E(i))
-			divisors = append(divisors, E(n/i))
-		}
-	}
-	divisors = append(divisors, E(n))
-	return RemoveDuplicates(divisors)
-}
-
-// returns a slice with all permutations of the given slice
+// returns a slice with all len(arr)! permutations of the given slice, in lexicographic
+// order of the element positions. Equal elements are not merged, use LexPermutations
+// to get every distinct permutation once. An empty slice has no permutations here.
+// Earlier versions used Heap's algorithm and returned the permutations in its order.
 func Permutations[E Comparable](arr []E) [][]E {
-	var helper func([]E, int)
 	res := [][]E{}
-
-	helper = func(arr []E, n int) {
-		if n == 1 {
-			tmp := make([]E, len(arr))
-			copy(tmp, arr)
-			res = append(res, tmp)
-		} else {
-			for i := range n {
-				helper(arr, n-1)
-				if n%2 == 1 {
-					tmp := arr[i]
-					arr[i] = arr[n-1]
-					arr[n-1] = tmp
-				} else {
-					tmp := arr[0]
-					arr[0] = arr[n-1]
-					arr[n-1] = tmp
-				}
-			}
+	if len(arr) == 0 {
+		return res
+	}
+	for idx := range LexPermutations(Range(0, len(arr))) {
+		perm := make([]E, len(arr))
+		for i, j := range idx {
+			perm[i] = arr[j]
 		}
+		res = append(res, perm)
 	}
-	helper(arr, len(arr))
 	return res
 }
 
-// returns the amount of permutations of the given slice
+// returns the amount of distinct permutations of the given slice.
+// The result overflows for more than 20 elements, use Multinomial with the element counts for those.
 func PermutationCount[E comparable](n []E) int {
 	elements := UniqueCount(n)
 	res := Factorial(len(n))
@@ -76,21 +36,37 @@ func PermutationCount[E comparable](n []E) int {
 	return res
 }
 
-// Returns all combinations of the elements in the given slice
+// Returns all combinations of the elements in the given slice with n elements, in the order
+// of their bitmasks. For n <= 0 all non-empty subsets are returned, which needs len(set) < 64.
+// Use KCombinations to iterate over the combinations without building the whole list.
 func Combinations[E any, F Integer](set []E, n F) (subsets [][]E) { // https://github.com/mxschmitt/golang-combinations/blob/master/combinations.go
-	length := uint(len(set))
+	length := len(set)
 
-	if n > F(len(set)) {
-		n = F(len(set))
+	if n > F(length) {
+		n = F(length)
 	}
 
-	// Go through all possible combinations of objects
-	// from 1 (only first object in subset) to 2^length (all objects in subset)
-	for subsetBits := 1; subsetBits < (1 << length); subsetBits++ {
-		if n > 0 && F(bits.OnesCount(uint(subsetBits))) != n {
-			continue
+	if n > 0 {
+		// walk the index sets in colexicographic order, which is the same as the bitmask order
+		idx := Range(0, int(n))
+		for {
+			subset := make([]E, len(idx))
+			for i, j := range idx {
+				subset[i] = set[j]
+			}
+			subsets = append(subsets, subset)
+			if !nextColexCombination(idx, length) {
+				return subsets
+			}
 		}
+	}
 
+	if length >= 64 {
+		panic("Combinations of all lengths needs fewer than 64 elements")
+	}
+	// Go through all possible combinations of objects
+	// from 1 (only first object in subset) to 2^length (all objects in subset)
+	for subsetBits := uint64(1); subsetBits < (1 << length); subsetBits++ {
 		var subset []E
 
 		for object := range length {
@@ -119,17 +95,28 @@ func Factorial[E Integer](n E) E {
 	return res
 }
 
-// Return the factorial as a Big Integer
+// Return the factorial as a Big Integer, and 1 for n < 2. See FactorialBig.
 func FactorialBigInt(n int64) *big.Int {
-	res := big.NewInt(1)
-	for i := n; i > 1; i-- {
-		res.Mul(res, big.NewInt(i))
+	if n < 2 {
+		return big.NewInt(1)
 	}
-	return res
+	return FactorialBig(n)
 }
 
-// Calculates factorial digital sum
+// Calculates the sum of the factorials of the digits of n.
+//
+// Deprecated: The name suggests the digit sum of n!, use DigitFactorialSum for this
+// function and FactorialDigitSumBase for the digit sum of n!.
 func FactorialDigitSum[E Integer](n E) E {
+	return DigitFactorialSum(n)
+}
+
+// Calculates the sum of the factorials of the digits of n
+//
+// Example:
+// s := DigitFactorialSum(145)
+// // s == 145 (1! + 4! + 5!)
+func DigitFactorialSum[E Integer](n E) E {
 	s := strconv.Itoa(int(n))
 	res := E(0)
 
@@ -282,37 +269,83 @@ func PrimeFactorsBigInt(n *big.Int) (primefs [][]int64) {
 	return primefs
 }
 
-// Calculates b^n using integers
+// Calculates b^n using integers by repeated squaring.
+// Non-positive exponents return 1.
 func Pow[E Integer](b E, n E) E {
-	if n == 1 {
-		return b
-	}
 	res := E(1)
-	for i := E(1); i <= n; i++ {
-		res *= b
+	for n > 0 {
+		if n&1 == 1 {
+			res *= b
+		}
+		n >>= 1
+		if n > 0 {
+			b *= b
+		}
 	}
 	return res
 }
 
-// Calculates b^n as a Big Integer
-func PowBigInt(b *big.Int, n int64) *big.Int {
-	if n == 1 {
-		return b
+// PowMonoid calculates b^n for any type with an associative multiplication,
+// such as matrices, polynomials or modular integers, using O(log n) calls to mul.
+// identity is the neutral element of mul and is returned for n == 0.
+func PowMonoid[T any](b T, n uint64, identity T, mul func(T, T) T) T {
+	res := identity
+	for n > 0 {
+		if n&1 == 1 {
+			res = mul(res, b)
+		}
+		n >>= 1
+		if n > 0 {
+			b = mul(b, b)
+		}
 	}
-	tmp := big.NewInt(b.Int64())
-	for i := int64(1); i <= n; i++ {
-		b.Mul(b, tmp)
+	return res
+}
+
+// PowBigInt sets z to b^n and returns z. Like the math/big methods, b is left
+// untouched and z may alias b. If z is nil a new big.Int is allocated.
+// Non-positive exponents yield 1.
+func PowBigInt(z, b *big.Int, n int64) *big.Int {
+	if z == nil {
+		z = new(big.Int)
+	}
+	if n <= 0 {
+		return z.SetInt64(1)
 	}
-	return b
+	return z.Exp(b, big.NewInt(n), nil)
 }
 
-// Calculates b^n as a Big Float
-func PowBigFloat(b *big.Float, n int64) *big.Float {
-	tmp := new(big.Float).Copy(b)
-	for i := int64(1); i < n; i++ {
-		b.Mul(b, tmp)
+// PowBigFloat sets z to b^n and returns z. Like the math/big methods, b is left
+// untouched and z may alias b. If z is nil a new big.Float is allocated.
+// The result is rounded to z's precision, or to b's precision if z's is 0.
+// Negative exponents yield 1/b^-n.
+func PowBigFloat(z, b *big.Float, n int64) *big.Float {
+	if z == nil {
+		z = new(big.Float)
+	}
+	prec := z.Prec()
+	if prec == 0 {
+		prec = b.Prec()
+	}
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+	base := new(big.Float).SetPrec(prec).Set(b)
+	res := new(big.Float).SetPrec(prec).SetInt64(1)
+	for n > 0 {
+		if n&1 == 1 {
+			res.Mul(res, base)
+		}
+		n >>= 1
+		if n > 0 {
+			base.Mul(base, base)
+		}
+	}
+	if neg {
+		res.Quo(new(big.Float).SetPrec(prec).SetInt64(1), res)
 	}
-	return b
+	return z.SetPrec(prec).Set(res)
 }
 
 // Checks whether the given number is a power of 2
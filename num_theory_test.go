@@ -1,6 +1,7 @@
 package eulerlib
 
 import (
+	"math/big"
 	"strconv"
 	"testing"
 )
@@ -251,3 +252,59 @@ func TestCombinations(t *testing.T) {
 		}
 	}
 }
+
+func TestPow(t *testing.T) {
+	testNums := [][]int64{{2, 0}, {2, 1}, {2, 10}, {3, 13}, {-3, 3}, {10, 18}, {7, -1}}
+	want := []int64{1, 2, 1024, 1594323, -27, 1000000000000000000, 1}
+	for i, num := range testNums {
+		got := Pow(num[0], num[1])
+		if got != want[i] {
+			t.Errorf("Pow(%d) == %d, want %d", num, got, want[i])
+		}
+	}
+}
+
+func TestPowMonoid(t *testing.T) {
+	// 2x2 matrix power yields Fibonacci numbers: [[1,1],[1,0]]^n = [[F(n+1),F(n)],[F(n),F(n-1)]]
+	type mat [4]int64
+	mul := func(a, b mat) mat {
+		return mat{
+			a[0]*b[0] + a[1]*b[2], a[0]*b[1] + a[1]*b[3],
+			a[2]*b[0] + a[3]*b[2], a[2]*b[1] + a[3]*b[3],
+		}
+	}
+	got := PowMonoid(mat{1, 1, 1, 0}, 90, mat{1, 0, 0, 1}, mul)
+	if got[1] != 2880067194370816120 {
+		t.Errorf("PowMonoid(fib matrix, 90)[1] == %d, want %d", got[1], int64(2880067194370816120))
+	}
+	if s := PowMonoid("ab", 3, "", func(a, b string) string { return a + b }); s != "ababab" {
+		t.Errorf("PowMonoid(\"ab\", 3) == %q, want %q", s, "ababab")
+	}
+}
+
+func TestPowBig(t *testing.T) {
+	b, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	got := PowBigInt(nil, b, 3)
+	want, _ := new(big.Int).SetString("1881676372353657772546716040589641726257477229849409426207693797722198701224860897069000", 10)
+	if got.Cmp(want) != 0 {
+		t.Errorf("PowBigInt(%s, 3) == %s, want %s", b, got, want)
+	}
+	if b.String() != "123456789012345678901234567890" {
+		t.Errorf("PowBigInt mutated its base: %s", b)
+	}
+	if got := PowBigInt(b, b, 0); got.Int64() != 1 || got != b {
+		t.Errorf("PowBigInt(b, b, 0) == %s, want 1 stored in b", got)
+	}
+
+	f := big.NewFloat(1.5)
+	gotf := PowBigFloat(new(big.Float), f, 5)
+	if v, _ := gotf.Float64(); v != 7.59375 {
+		t.Errorf("PowBigFloat(1.5, 5) == %v, want 7.59375", v)
+	}
+	if v, _ := PowBigFloat(nil, big.NewFloat(2), -3).Float64(); v != 0.125 {
+		t.Errorf("PowBigFloat(2, -3) == %v, want 0.125", v)
+	}
+	if v, _ := f.Float64(); v != 1.5 {
+		t.Errorf("PowBigFloat mutated its base: %v", v)
+	}
+}
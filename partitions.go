@@ -0,0 +1,191 @@
+package eulerlib
+
+import (
+	"iter"
+	"math/big"
+)
+
+// ListPartitionCounts returns p(0), ..., p(n), the number of partitions of each integer,
+// using Euler's pentagonal number theorem
+// p(n) = Σ (-1)^(k+1)·(p(n - k(3k-1)/2) + p(n - k(3k+1)/2)) for k >= 1,
+// which needs O(sqrt(n)) terms per value.
+func ListPartitionCounts(n int) []*big.Int {
+	if n < 0 {
+		return nil
+	}
+	p := make([]*big.Int, n+1)
+	p[0] = big.NewInt(1)
+	for i := 1; i <= n; i++ {
+		p[i] = new(big.Int)
+		for k := 1; ; k++ {
+			g := k * (3*k - 1) / 2
+			if g > i {
+				break
+			}
+			term := new(big.Int).Set(p[i-g])
+			if g+k <= i {
+				term.Add(term, p[i-g-k])
+			}
+			if k%2 == 1 {
+				p[i].Add(p[i], term)
+			} else {
+				p[i].Sub(p[i], term)
+			}
+		}
+	}
+	return p
+}
+
+// PartitionCount returns p(n), the number of ways to write n as a sum of positive integers
+// without regard to order, and 0 for negative n.
+//
+// Example:
+// c := PartitionCount(100)
+// // c == 190569292
+func PartitionCount(n int) *big.Int {
+	if n < 0 {
+		return new(big.Int)
+	}
+	return ListPartitionCounts(n)[n]
+}
+
+// ListPartitionCountsMod returns p(0), ..., p(n) modulo m, for a modulus m >= 1 below 2^62
+func ListPartitionCountsMod(n int, m int64) []int64 {
+	if m < 1 {
+		panic("modulus must be positive")
+	}
+	if n < 0 {
+		return nil
+	}
+	p := make([]int64, n+1)
+	p[0] = 1 % m
+	for i := 1; i <= n; i++ {
+		var sum int64
+		for k := 1; ; k++ {
+			g := k * (3*k - 1) / 2
+			if g > i {
+				break
+			}
+			term := p[i-g]
+			if g+k <= i {
+				term = (term + p[i-g-k]) % m
+			}
+			if k%2 == 1 {
+				sum += term
+			} else {
+				sum += m - term
+			}
+			sum %= m
+		}
+		p[i] = sum
+	}
+	return p
+}
+
+// PartitionCountMod returns p(n) mod m
+func PartitionCountMod(n int, m int64) int64 {
+	if n < 0 {
+		return 0
+	}
+	return ListPartitionCountsMod(n, m)[n]
+}
+
+// PartitionCountParts returns the number of partitions of n into parts taken from parts,
+// each usable any number of times, such as the ways to make an amount from coins.
+// Repeated values in parts are counted once.
+//
+// Example:
+// c := PartitionCountParts(200, []int{1, 2, 5, 10, 20, 50, 100, 200})
+// // c == 73682
+func PartitionCountParts(n int, parts []int) *big.Int {
+	if n < 0 {
+		return new(big.Int)
+	}
+	ways := make([]*big.Int, n+1)
+	for i := range ways {
+		ways[i] = new(big.Int)
+	}
+	ways[0].SetInt64(1)
+	for _, part := range RemoveDuplicates(parts) {
+		if part < 1 {
+			panic("parts must be positive")
+		}
+		for i := part; i <= n; i++ {
+			ways[i].Add(ways[i], ways[i-part])
+		}
+	}
+	return ways[n]
+}
+
+// PartitionCountAtMostK returns the number of partitions of n into at most k parts,
+// which by conjugation equals the number of partitions of n into parts no larger than k
+func PartitionCountAtMostK(n, k int) *big.Int {
+	return PartitionCountParts(n, Range(1, max(k, 0)+1))
+}
+
+// DistinctPartitionCount returns q(n), the number of partitions of n into distinct parts,
+// which equals the number of partitions into odd parts
+func DistinctPartitionCount(n int) *big.Int {
+	if n < 0 {
+		return new(big.Int)
+	}
+	ways := make([]*big.Int, n+1)
+	for i := range ways {
+		ways[i] = new(big.Int)
+	}
+	ways[0].SetInt64(1)
+	// each part is used at most once, so the sums are updated from the top down
+	for part := 1; part <= n; part++ {
+		for i := n; i >= part; i-- {
+			ways[i].Add(ways[i], ways[i-part])
+		}
+	}
+	return ways[n]
+}
+
+// Partitions returns an iterator over all partitions of n in reverse lexicographic order,
+// each as a non-increasing slice of parts, starting with [n] and ending with n ones.
+// For n == 0 it yields the empty partition once and for negative n nothing.
+//
+// Example:
+// for p := range Partitions(4) { ... }
+// // yields [4], [3 1], [2 2], [2 1 1], [1 1 1 1]
+func Partitions(n int) iter.Seq[[]int] {
+	return clones(PartitionsReuse(n))
+}
+
+// PartitionsReuse is like Partitions, but yields the same buffer at every step,
+// which is only valid until the next one
+func PartitionsReuse(n int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if n < 0 {
+			return
+		}
+		buf := make([]int, 0, n)
+		if n > 0 {
+			buf = append(buf, n)
+		}
+		for yield(buf) {
+			// remove the trailing ones and decrease the last part larger than one
+			ones := 0
+			for len(buf) > 0 && buf[len(buf)-1] == 1 {
+				buf = buf[:len(buf)-1]
+				ones++
+			}
+			if len(buf) == 0 {
+				return
+			}
+			v := buf[len(buf)-1] - 1
+			buf[len(buf)-1] = v
+			// spread the freed amount over parts of size v and the remainder
+			rem := ones + 1
+			for rem >= v {
+				buf = append(buf, v)
+				rem -= v
+			}
+			if rem > 0 {
+				buf = append(buf, rem)
+			}
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package eulerlib
+
+import (
+	"math/big"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestPartitionCount(t *testing.T) {
+	// OEIS A000041
+	want := []int64{1, 1, 2, 3, 5, 7, 11, 15, 22, 30, 42, 56, 77, 101, 135, 176, 231, 297, 385, 490}
+	for i, p := range ListPartitionCounts(len(want) - 1) {
+		if p.Int64() != want[i] {
+			t.Errorf("ListPartitionCounts()[%d] == %s, want %d", i, p, want[i])
+		}
+	}
+	big1000, _ := new(big.Int).SetString("24061467864032622473692149727991", 10)
+	tests := []struct {
+		n    int
+		want *big.Int
+	}{
+		{100, big.NewInt(190569292)},
+		{1000, big1000},
+		{-1, big.NewInt(0)},
+	}
+	for _, tt := range tests {
+		if got := PartitionCount(tt.n); got.Cmp(tt.want) != 0 {
+			t.Errorf("PartitionCount(%d) == %s, want %s", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestPartitionCountMod(t *testing.T) {
+	exact := ListPartitionCounts(300)
+	mod := ListPartitionCountsMod(300, 1000003)
+	for i := range exact {
+		if want := new(big.Int).Mod(exact[i], big.NewInt(1000003)).Int64(); mod[i] != want {
+			t.Errorf("ListPartitionCountsMod(300, 1000003)[%d] == %d, want %d", i, mod[i], want)
+		}
+	}
+
+	// Project Euler 78: the least n with p(n) divisible by one million
+	first := -1
+	for i, p := range ListPartitionCountsMod(60000, 1000000) {
+		if p == 0 {
+			first = i
+			break
+		}
+	}
+	if first != 55374 {
+		t.Errorf("least n with 10^6 | p(n) == %d, want %d", first, 55374)
+	}
+	if got := PartitionCountMod(5, 1); got != 0 {
+		t.Errorf("PartitionCountMod(5, 1) == %d, want 0", got)
+	}
+}
+
+func TestRestrictedPartitionCounts(t *testing.T) {
+	coins := []int{1, 2, 5, 10, 20, 50, 100, 200}
+	if got := PartitionCountParts(200, coins); got.Int64() != 73682 {
+		t.Errorf("PartitionCountParts(200, coins) == %s, want %d", got, 73682)
+	}
+	if got := PartitionCountParts(10, []int{3, 3}); got.Int64() != 0 {
+		t.Errorf("PartitionCountParts(10, [3 3]) == %s, want 0", got)
+	}
+	// Project Euler 76: sums of at least two positive integers
+	if got := PartitionCountAtMostK(100, 99); got.Int64() != 190569291 {
+		t.Errorf("PartitionCountAtMostK(100, 99) == %s, want %d", got, 190569291)
+	}
+	// p(10, <= 3 parts) = 14 (OEIS A001399)
+	if got := PartitionCountAtMostK(10, 3); got.Int64() != 14 {
+		t.Errorf("PartitionCountAtMostK(10, 3) == %s, want %d", got, 14)
+	}
+
+	// OEIS A000009
+	want := []int64{1, 1, 1, 2, 2, 3, 4, 5, 6, 8, 10, 12, 15, 18, 22, 27}
+	for n, w := range want {
+		if got := DistinctPartitionCount(n); got.Int64() != w {
+			t.Errorf("DistinctPartitionCount(%d) == %s, want %d", n, got, w)
+		}
+	}
+	if got := DistinctPartitionCount(100); got.Int64() != 444793 {
+		t.Errorf("DistinctPartitionCount(100) == %s, want %d", got, 444793)
+	}
+}
+
+func TestPartitions(t *testing.T) {
+	got := slices.Collect(Partitions(5))
+	want := [][]int{{5}, {4, 1}, {3, 2}, {3, 1, 1}, {2, 2, 1}, {2, 1, 1, 1}, {1, 1, 1, 1, 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Partitions(5) == %v, want %v", got, want)
+	}
+
+	for n := range 20 {
+		count := int64(0)
+		for p := range Partitions(n) {
+			if Sum(p) != n {
+				t.Fatalf("Partitions(%d) yielded %v", n, p)
+			}
+			count++
+		}
+		if want := PartitionCount(n).Int64(); count != want {
+			t.Errorf("Partitions(%d) yielded %d partitions, want %d", n, count, want)
+		}
+	}
+	i := 0
+	for p := range PartitionsReuse(5) {
+		if !slices.Equal(p, want[i]) {
+			t.Errorf("PartitionsReuse(5) yielded %v at %d, want %v", p, i, want[i])
+		}
+		i++
+	}
+	for p := range Partitions(-1) {
+		t.Errorf("Partitions(-1) yielded %v, want nothing", p)
+	}
+}
@@ -0,0 +1,177 @@
+package eulerlib
+
+import "math/big"
+
+// PascalRow returns the binomial coefficients C(n, 0), ..., C(n, n),
+// using C(n, k) = C(n, k-1)·(n-k+1)/k
+//
+// Example:
+// r := PascalRow(5)
+// // r == [1 5 10 10 5 1]
+func PascalRow(n int) []*big.Int {
+	if n < 0 {
+		return nil
+	}
+	row := make([]*big.Int, n+1)
+	row[0] = big.NewInt(1)
+	for k := 1; k <= n; k++ {
+		row[k] = new(big.Int).Mul(row[k-1], big.NewInt(int64(n-k+1)))
+		row[k].Quo(row[k], big.NewInt(int64(k)))
+	}
+	return row
+}
+
+// PascalRowMod returns C(n, 0), ..., C(n, n) modulo any m >= 1. It follows the same
+// recurrence as PascalRow, but keeps the prime factors of m apart as exponents so that the
+// remaining division is by a unit, which takes O(n·ω(m)) steps for ω(m) distinct primes.
+func PascalRowMod(n int, m int64) []int64 {
+	if m < 1 {
+		panic("modulus must be positive")
+	}
+	if n < 0 {
+		return nil
+	}
+	ps, _ := primePowers(m)
+	exps := make([]int64, len(ps))
+	row := make([]int64, n+1)
+	unit := 1 % m
+	row[0] = unit
+	for k := 1; k <= n; k++ {
+		num, den := int64(n-k+1), int64(k)
+		for i, p := range ps {
+			for ; num%p == 0; num /= p {
+				exps[i]++
+			}
+			for ; den%p == 0; den /= p {
+				exps[i]--
+			}
+		}
+		unit = mulMod(mulMod(unit, num%m, m), invMod(den, m), m)
+		row[k] = unit
+		for i, p := range ps {
+			row[k] = mulMod(row[k], PowMonoid(p%m, uint64(exps[i]), 1%m, func(a, b int64) int64 { return mulMod(a, b, m) }), m)
+		}
+	}
+	return row
+}
+
+// Multinomial returns the multinomial coefficient (k1 + k2 + ...)! / (k1!·k2!·...), the number
+// of distinct arrangements of a multiset with the given multiplicities, computed as a product
+// of binomial coefficients. It panics on negative multiplicities.
+//
+// Example:
+// c := Multinomial([]int{1, 4, 4, 2}) // the letters of "mississippi"
+// // c == 34650
+func Multinomial(ks []int) *big.Int {
+	res, n := big.NewInt(1), 0
+	for _, k := range ks {
+		if k < 0 {
+			panic("multiplicities must not be negative")
+		}
+		n += k
+		res.Mul(res, Binomial(n, k))
+	}
+	return res
+}
+
+// MultinomialMod returns the multinomial coefficient of ks modulo any m >= 1, as a product
+// of BinomialMod results
+func MultinomialMod(ks []int, m int64) int64 {
+	if m < 1 {
+		panic("modulus must be positive")
+	}
+	res, n := 1%m, int64(0)
+	for _, k := range ks {
+		if k < 0 {
+			panic("multiplicities must not be negative")
+		}
+		n += int64(k)
+		res = mulMod(res, BinomialMod(n, int64(k), m), m)
+	}
+	return res
+}
+
+// LegendreValuation returns the exponent of the prime p in n!, by Legendre's formula
+// Σ floor(n/p^i) for i >= 1
+//
+// Example:
+// v := LegendreValuation(100, 5)
+// // v == 24
+func LegendreValuation(n, p int64) (v int64) {
+	if p < 2 {
+		panic("p must be a prime")
+	}
+	for n > 0 {
+		n /= p
+		v += n
+	}
+	return
+}
+
+// KummerValuation returns the exponent of the prime p in C(n, k). By Kummer's theorem it is
+// the number of carries when adding k and n-k in base p. It returns 0 if k is not in [0, n].
+//
+// Example:
+// v := KummerValuation(10, 3, 2)
+// // v == 3 (C(10, 3) == 120 == 2³·15)
+func KummerValuation(n, k, p int64) (v int64) {
+	if p < 2 {
+		panic("p must be a prime")
+	}
+	if k < 0 || k > n {
+		return 0
+	}
+	carry := int64(0)
+	for a, b := k, n-k; a > 0 || b > 0; a, b = a/p, b/p {
+		carry = (a%p + b%p + carry) / p
+		v += carry
+	}
+	return
+}
+
+// CountNonDivisibleInRow returns the number of entries of row n of Pascal's triangle that are
+// not divisible by the prime p, which by Lucas' theorem is the product of (d+1) over the
+// base p digits d of n
+//
+// Example:
+// c := CountNonDivisibleInRow(10, 2) // 10 == 1010 in binary
+// // c == 4
+func CountNonDivisibleInRow(n, p int64) int64 {
+	if n < 0 || p < 2 {
+		panic("CountNonDivisibleInRow requires n >= 0 and a prime p")
+	}
+	res := int64(1)
+	for ; n > 0; n /= p {
+		res *= n%p + 1
+	}
+	return res
+}
+
+// CountNonDivisibleInRows returns the number of entries in the first n rows 0..n-1 of
+// Pascal's triangle that are not divisible by the prime p. The first p^j rows contain
+// (p(p+1)/2)^j of them, so the count is assembled digit by digit from the most
+// significant base p digit of n.
+//
+// Example:
+// c := CountNonDivisibleInRows(1000000000, 7)
+// // c == 2129970655314432
+func CountNonDivisibleInRows(n, p int64) *big.Int {
+	if n < 0 || p < 2 {
+		panic("CountNonDivisibleInRows requires n >= 0 and a prime p")
+	}
+	var digits []int64
+	for ; n > 0; n /= p {
+		digits = append(digits, n%p)
+	}
+	tri := big.NewInt(p * (p + 1) / 2)
+	res, mult, block := new(big.Int), big.NewInt(1), new(big.Int)
+	for j := len(digits) - 1; j >= 0; j-- {
+		// the d blocks of p^j rows before the current one contribute 1 + 2 + ... + d times a full block
+		d := digits[j]
+		block.Exp(tri, big.NewInt(int64(j)), nil)
+		block.Mul(block, big.NewInt(d*(d+1)/2))
+		res.Add(res, block.Mul(block, mult))
+		mult.Mul(mult, big.NewInt(d+1))
+	}
+	return res
+}
@@ -0,0 +1,106 @@
+package eulerlib
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestPascalRow(t *testing.T) {
+	for n := range 70 {
+		row := PascalRow(n)
+		if len(row) != n+1 {
+			t.Fatalf("len(PascalRow(%d)) == %d, want %d", n, len(row), n+1)
+		}
+		for k, c := range row {
+			if want := Binomial(n, k); c.Cmp(want) != 0 {
+				t.Errorf("PascalRow(%d)[%d] == %s, want %s", n, k, c, want)
+			}
+		}
+	}
+}
+
+func TestPascalRowMod(t *testing.T) {
+	for _, m := range []int64{1, 2, 12, 360, 1000000, 1000000007} {
+		for _, n := range []int{0, 1, 9, 64, 100} {
+			exact := PascalRow(n)
+			for k, got := range PascalRowMod(n, m) {
+				if want := new(big.Int).Mod(exact[k], big.NewInt(m)).Int64(); got != want {
+					t.Errorf("PascalRowMod(%d, %d)[%d] == %d, want %d", n, m, k, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestMultinomial(t *testing.T) {
+	if got := Multinomial([]int{1, 4, 4, 2}); got.Int64() != 34650 {
+		t.Errorf("Multinomial([1 4 4 2]) == %s, want %d", got, 34650)
+	}
+	if got := Multinomial(nil); got.Int64() != 1 {
+		t.Errorf("Multinomial(nil) == %s, want 1", got)
+	}
+	// agrees with PermutationCount
+	s := []byte("abracadabra")
+	counts := []int{}
+	for _, c := range UniqueCount(s) {
+		counts = append(counts, c)
+	}
+	if got := Multinomial(counts); got.Int64() != int64(PermutationCount(s)) {
+		t.Errorf("Multinomial(%v) == %s, want %d", counts, got, PermutationCount(s))
+	}
+
+	ks := []int{30, 20, 10, 5}
+	exact := Multinomial(ks)
+	for _, m := range []int64{1, 1000, 1000000007} {
+		if got, want := MultinomialMod(ks, m), new(big.Int).Mod(exact, big.NewInt(m)).Int64(); got != want {
+			t.Errorf("MultinomialMod(%v, %d) == %d, want %d", ks, m, got, want)
+		}
+	}
+}
+
+func TestLegendreValuation(t *testing.T) {
+	tests := [][3]int64{{100, 5, 24}, {100, 2, 97}, {0, 3, 0}, {1000000000000, 7, 166666666660}}
+	for _, tt := range tests {
+		if got := LegendreValuation(tt[0], tt[1]); got != tt[2] {
+			t.Errorf("LegendreValuation(%d, %d) == %d, want %d", tt[0], tt[1], got, tt[2])
+		}
+	}
+}
+
+func TestKummerValuation(t *testing.T) {
+	for _, p := range []int64{2, 3, 5, 7} {
+		for n := int64(0); n < 60; n++ {
+			for k := int64(0); k <= n; k++ {
+				want := LegendreValuation(n, p) - LegendreValuation(k, p) - LegendreValuation(n-k, p)
+				if got := KummerValuation(n, k, p); got != want {
+					t.Errorf("KummerValuation(%d, %d, %d) == %d, want %d", n, k, p, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestCountNonDivisible(t *testing.T) {
+	for _, p := range []int64{2, 3, 7} {
+		total := int64(0)
+		for n := int64(0); n < 100; n++ {
+			if got := CountNonDivisibleInRows(n, p); got.Int64() != total {
+				t.Errorf("CountNonDivisibleInRows(%d, %d) == %s, want %d", n, p, got, total)
+			}
+			want := int64(0)
+			for _, c := range PascalRowMod(int(n), p) {
+				if c != 0 {
+					want++
+				}
+			}
+			if got := CountNonDivisibleInRow(n, p); got != want {
+				t.Errorf("CountNonDivisibleInRow(%d, %d) == %d, want %d", n, p, got, want)
+			}
+			total += want
+		}
+	}
+	// Project Euler 148
+	if got := CountNonDivisibleInRows(1000000000, 7); got.Int64() != 2129970655314432 {
+		t.Errorf("CountNonDivisibleInRows(10^9, 7) == %s, want %d", got, int64(2129970655314432))
+	}
+}
@@ -0,0 +1,255 @@
+package eulerlib
+
+import (
+	"iter"
+	"math/big"
+)
+
+// SolvePell returns the fundamental solution of x² - D·y² = 1, the one with the smallest positive y.
+// It is the convergent of sqrt(D) at index r-1 for an even period length r and at 2r-1 for an odd one.
+// It panics if D is not a positive non-square.
+//
+// Example:
+// x, y := SolvePell(61)
+// // x == 1766319049, y == 226153980
+func SolvePell(D int64) (x, y *big.Int) {
+	a0, period := pellPeriod(D)
+	r := len(period)
+	if r%2 == 0 {
+		return nthConvergent(a0, period, r-1)
+	}
+	return nthConvergent(a0, period, 2*r-1)
+}
+
+// SolveNegativePell returns the fundamental solution of x² - D·y² = -1,
+// and false if there is none, which is the case exactly when the period of sqrt(D) is even
+func SolveNegativePell(D int64) (x, y *big.Int, ok bool) {
+	a0, period := pellPeriod(D)
+	r := len(period)
+	if r%2 == 0 {
+		return nil, nil, false
+	}
+	x, y = nthConvergent(a0, period, r-1)
+	return x, y, true
+}
+
+// PellSolutions returns an infinite iterator over all positive solutions of x² - D·y² = 1
+// in increasing order, generated from the fundamental solution (x1, y1) by
+// x(k+1) + y(k+1)·sqrt(D) = (x(k) + y(k)·sqrt(D))·(x1 + y1·sqrt(D))
+func PellSolutions(D int64) iter.Seq2[*big.Int, *big.Int] {
+	return func(yield func(*big.Int, *big.Int) bool) {
+		x1, y1 := SolvePell(D)
+		x, y := new(big.Int).Set(x1), new(big.Int).Set(y1)
+		for yield(new(big.Int).Set(x), new(big.Int).Set(y)) {
+			x, y = pellStep(D, x, y, x1, y1)
+		}
+	}
+}
+
+// SolveGeneralizedPell returns the fundamental solution, the one with the smallest y >= 0,
+// of every class of solutions of x² - D·y² = N, found with the Lagrange–Matthews–Mollin
+// algorithm. Every solution of the equation is ±(x + y·sqrt(D))·(x1 + y1·sqrt(D))^k for
+// one of the returned (x, y), with (x1, y1) from SolvePell and k an integer.
+// It panics if D is not a positive non-square or N is 0.
+func SolveGeneralizedPell(D, N int64) (res [][2]*big.Int) {
+	if N == 0 {
+		panic("N must not be zero")
+	}
+	t, u, negative := SolveNegativePell(D)
+	x1, y1 := SolvePell(D)
+	seen := make(map[string]bool)
+	add := func(x, y *big.Int) {
+		c := fundamentalPell(D, N, x, y, x1, y1)
+		if key := c[0].String() + "," + c[1].String(); !seen[key] {
+			seen[key] = true
+			res = append(res, c)
+		}
+	}
+	absN := max(N, -N)
+	for f := int64(1); f*f <= absN; f++ {
+		if N%(f*f) != 0 {
+			continue
+		}
+		m := N / (f * f)
+		absM := max(m, -m)
+		bigF := big.NewInt(f)
+		for z := -(absM - 1) / 2; z <= absM/2; z++ {
+			if normMod(z*z-D, absM) != 0 {
+				continue
+			}
+			r, s, ok := lmmPQa(D, z, absM)
+			if !ok {
+				continue
+			}
+			// r² - D·s² is either m or -m
+			norm := new(big.Int).Sub(new(big.Int).Mul(r, r), new(big.Int).Mul(big.NewInt(D), new(big.Int).Mul(s, s)))
+			switch {
+			case norm.Cmp(big.NewInt(m)) == 0:
+				add(r.Mul(r, bigF), s.Mul(s, bigF))
+			case negative:
+				// multiply by the solution of x² - D·y² = -1 to flip the sign of the norm
+				x := new(big.Int).Add(new(big.Int).Mul(r, t), new(big.Int).Mul(new(big.Int).Mul(s, u), big.NewInt(D)))
+				y := new(big.Int).Add(new(big.Int).Mul(r, u), new(big.Int).Mul(s, t))
+				add(x.Mul(x, bigF), y.Mul(y, bigF))
+			}
+		}
+	}
+	return
+}
+
+// GeneralizedPellSolutions returns an infinite iterator over all solutions of
+// x² - D·y² = N with x > 0 and y >= 0, in increasing order of x. It merges the
+// classes found by SolveGeneralizedPell and yields nothing if there are none.
+func GeneralizedPellSolutions(D, N int64) iter.Seq2[*big.Int, *big.Int] {
+	return func(yield func(*big.Int, *big.Int) bool) {
+		x1, y1 := SolvePell(D)
+		classes := [][2]*big.Int{}
+		for _, c := range SolveGeneralizedPell(D, N) {
+			// the orbits of ±(x ± y·sqrt(D)) contain all solutions of this class and its conjugate
+			for _, sx := range []int64{1, -1} {
+				for _, sy := range []int64{1, -1} {
+					x := new(big.Int).Mul(c[0], big.NewInt(sx))
+					y := new(big.Int).Mul(c[1], big.NewInt(sy))
+					if pellSign(N, x, y) > 0 {
+						classes = append(classes, firstPositivePell(D, x, y, x1, y1))
+					}
+				}
+			}
+		}
+		if len(classes) == 0 {
+			return
+		}
+		var last *big.Int
+		for {
+			best := 0
+			for i, c := range classes {
+				if c[0].Cmp(classes[best][0]) < 0 {
+					best = i
+				}
+			}
+			x, y := classes[best][0], classes[best][1]
+			if last == nil || x.Cmp(last) != 0 {
+				if !yield(new(big.Int).Set(x), new(big.Int).Set(y)) {
+					return
+				}
+				last = new(big.Int).Set(x)
+			}
+			nx, ny := pellStep(D, x, y, x1, y1)
+			classes[best] = [2]*big.Int{nx, ny}
+		}
+	}
+}
+
+// pellPeriod returns the continued fraction of sqrt(D) and panics if D is not a positive non-square
+func pellPeriod(D int64) (int64, []int64) {
+	if D < 1 {
+		panic("D must be positive")
+	}
+	a0, period := ContinuedFractionSqrt(D)
+	if len(period) == 0 {
+		panic("D must not be a perfect square")
+	}
+	return a0, period
+}
+
+// nthConvergent returns the convergent h/k at the given index of [a0; (period)]
+func nthConvergent(a0 int64, period []int64, index int) (h, k *big.Int) {
+	i := 0
+	for h, k = range Convergents(PeriodicTerms(a0, period)) {
+		if i == index {
+			break
+		}
+		i++
+	}
+	return
+}
+
+// pellStep returns (x + y·sqrt(D))·(x1 + y1·sqrt(D))
+func pellStep(D int64, x, y, x1, y1 *big.Int) (*big.Int, *big.Int) {
+	nx := new(big.Int).Mul(x, x1)
+	nx.Add(nx, new(big.Int).Mul(new(big.Int).Mul(y, y1), big.NewInt(D)))
+	ny := new(big.Int).Mul(x, y1)
+	ny.Add(ny, new(big.Int).Mul(y, x1))
+	return nx, ny
+}
+
+// pellSign returns the sign of the real number x + y·sqrt(D), where x² - D·y² = N
+func pellSign(N int64, x, y *big.Int) int {
+	if x.Sign() == y.Sign() || y.Sign() == 0 {
+		return x.Sign()
+	}
+	// |x| > |y|·sqrt(D) exactly when the norm N is positive
+	if N > 0 {
+		return x.Sign()
+	}
+	return y.Sign()
+}
+
+// fundamentalPell returns the element of the class ±(x + y·sqrt(D))·(x1 + y1·sqrt(D))^k
+// with the smallest |y|, signed so that y >= 0. Along the orbit |y| first falls and then rises.
+func fundamentalPell(D, N int64, x, y, x1, y1 *big.Int) [2]*big.Int {
+	if pellSign(N, x, y) < 0 {
+		x, y = new(big.Int).Neg(x), new(big.Int).Neg(y)
+	}
+	for _, unit := range []*big.Int{new(big.Int).Neg(y1), y1} {
+		for {
+			nx, ny := pellStep(D, x, y, x1, unit)
+			if new(big.Int).Abs(ny).Cmp(new(big.Int).Abs(y)) >= 0 {
+				break
+			}
+			x, y = nx, ny
+		}
+	}
+	if y.Sign() < 0 {
+		x, y = x.Neg(x), y.Neg(y)
+	}
+	return [2]*big.Int{x, y}
+}
+
+// firstPositivePell returns the smallest element of the orbit (x + y·sqrt(D))·(x1 + y1·sqrt(D))^k
+// with x > 0 and y >= 0, given that x + y·sqrt(D) is positive
+func firstPositivePell(D int64, x, y, x1, y1 *big.Int) [2]*big.Int {
+	negY1 := new(big.Int).Neg(y1)
+	for {
+		// dividing by the unit is multiplying by its conjugate
+		px, py := pellStep(D, x, y, x1, negY1)
+		if px.Sign() <= 0 || py.Sign() < 0 {
+			break
+		}
+		x, y = px, py
+	}
+	for x.Sign() <= 0 || y.Sign() < 0 {
+		x, y = pellStep(D, x, y, x1, y1)
+	}
+	return [2]*big.Int{x, y}
+}
+
+// lmmPQa runs the PQa continued fraction algorithm on (P0 + sqrt(D)) / Q0 until some
+// Q(i) is ±1, and returns the G(i-1), B(i-1) at that point. It reports false when
+// a whole period passes without that happening.
+func lmmPQa(D, P0, Q0 int64) (*big.Int, *big.Int, bool) {
+	sqrtD := isqrt(D)
+	P, Q := P0, Q0
+	g2, g1 := big.NewInt(-P0), big.NewInt(Q0)
+	b2, b1 := big.NewInt(1), big.NewInt(0)
+	seen := make(map[[2]int64]bool)
+	for !seen[[2]int64{P, Q}] {
+		seen[[2]int64{P, Q}] = true
+		// a = floor((P + sqrt(D)) / Q), where the irrational sqrt(D) can be replaced by its floor
+		var a int64
+		if Q > 0 {
+			a = floorDiv(P+sqrtD, Q)
+		} else {
+			a = -floorDiv(P+sqrtD, -Q) - 1
+		}
+		bigA := big.NewInt(a)
+		g2, g1 = g1, new(big.Int).Add(new(big.Int).Mul(bigA, g1), g2)
+		b2, b1 = b1, new(big.Int).Add(new(big.Int).Mul(bigA, b1), b2)
+		P = a*Q - P
+		Q = (D - P*P) / Q
+		if Q == 1 || Q == -1 {
+			return g1, b1, true
+		}
+	}
+	return nil, nil, false
+}
@@ -0,0 +1,118 @@
+package eulerlib
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestSolvePell(t *testing.T) {
+	testCases := []struct {
+		D    int64
+		x, y string
+	}{
+		{2, "3", "2"},
+		{13, "649", "180"},
+		{61, "1766319049", "226153980"},
+		{109, "158070671986249", "15140424455100"},
+	}
+	for _, tc := range testCases {
+		x, y := SolvePell(tc.D)
+		if x.String() != tc.x || y.String() != tc.y {
+			t.Errorf("SolvePell(%d) = %s, %s, want %s, %s", tc.D, x, y, tc.x, tc.y)
+		}
+	}
+	// Project Euler 66: D <= 1000 with the largest minimal x is 661
+	best, bestX := int64(0), big.NewInt(0)
+	for D := int64(2); D <= 1000; D++ {
+		if IsSquare(D) {
+			continue
+		}
+		if x, _ := SolvePell(D); x.Cmp(bestX) > 0 {
+			best, bestX = D, x
+		}
+	}
+	if best != 661 {
+		t.Errorf("D <= 1000 with the largest minimal Pell solution = %d, want 661", best)
+	}
+}
+
+func TestSolveNegativePell(t *testing.T) {
+	x, y, ok := SolveNegativePell(13)
+	if !ok || x.Int64() != 18 || y.Int64() != 5 {
+		t.Errorf("SolveNegativePell(13) = %s, %s, %t, want 18, 5, true", x, y, ok)
+	}
+	if _, _, ok := SolveNegativePell(3); ok {
+		t.Errorf("SolveNegativePell(3) reported a solution")
+	}
+}
+
+func TestPellSolutions(t *testing.T) {
+	want := [][2]int64{{3, 2}, {17, 12}, {99, 70}, {577, 408}}
+	i := 0
+	for x, y := range PellSolutions(2) {
+		if x.Int64() != want[i][0] || y.Int64() != want[i][1] {
+			t.Errorf("PellSolutions(2)[%d] = %s, %s, want %v", i, x, y, want[i])
+		}
+		i++
+		if i == len(want) {
+			break
+		}
+	}
+}
+
+func TestGeneralizedPellSolutions(t *testing.T) {
+	// compare with brute force over x <= limit
+	limit := int64(3000)
+	for D := int64(2); D <= 30; D++ {
+		if IsSquare(D) {
+			continue
+		}
+		for N := int64(-40); N <= 40; N++ {
+			if N == 0 {
+				continue
+			}
+			want := [][2]int64{}
+			for x := int64(1); x <= limit; x++ {
+				rem := x*x - N
+				if rem >= 0 && rem%D == 0 && IsSquare(rem/D) {
+					want = append(want, [2]int64{x, isqrt(rem / D)})
+				}
+			}
+			got := [][2]int64{}
+			for x, y := range GeneralizedPellSolutions(D, N) {
+				if x.Int64() > limit {
+					break
+				}
+				got = append(got, [2]int64{x.Int64(), y.Int64()})
+			}
+			if len(got) != len(want) {
+				t.Errorf("GeneralizedPellSolutions(%d, %d) = %v, want %v", D, N, got, want)
+				continue
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Errorf("GeneralizedPellSolutions(%d, %d) = %v, want %v", D, N, got, want)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestSolveGeneralizedPell(t *testing.T) {
+	got := map[[2]int64]bool{}
+	for _, c := range SolveGeneralizedPell(5, 44) {
+		got[[2]int64{c[0].Int64(), c[1].Int64()}] = true
+	}
+	want := map[[2]int64]bool{{7, 1}: true, {-7, 1}: true, {8, 2}: true, {-8, 2}: true, {13, 5}: true, {-13, 5}: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SolveGeneralizedPell(5, 44) = %v, want %v", got, want)
+	}
+	if got := SolveGeneralizedPell(2, -36); len(got) != 1 || got[0][0].Int64() != 6 || got[0][1].Int64() != 6 {
+		t.Errorf("SolveGeneralizedPell(2, -36) = %v, want [[6 6]]", got)
+	}
+	if got := SolveGeneralizedPell(3, -1); len(got) != 0 {
+		t.Errorf("SolveGeneralizedPell(3, -1) = %v, want no solutions", got)
+	}
+}
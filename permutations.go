@@ -0,0 +1,236 @@
+package eulerlib
+
+import (
+	"cmp"
+	"iter"
+	"math/big"
+	"slices"
+)
+
+// NextPermutation rearranges s into the next lexicographically greater permutation and
+// reports whether there was one. If s is already the last permutation it is reset to
+// the first one, sorted ascending, and false is returned. Repeated elements are handled,
+// so every distinct arrangement of a multiset is visited exactly once.
+func NextPermutation[E cmp.Ordered](s []E) bool {
+	// find the longest non-increasing suffix, s[i] is the pivot in front of it
+	i := len(s) - 2
+	for i >= 0 && s[i] >= s[i+1] {
+		i--
+	}
+	if i < 0 {
+		slices.Reverse(s)
+		return false
+	}
+	// swap the pivot with the rightmost element greater than it, then reverse the suffix
+	j := len(s) - 1
+	for s[j] <= s[i] {
+		j--
+	}
+	s[i], s[j] = s[j], s[i]
+	slices.Reverse(s[i+1:])
+	return true
+}
+
+// PrevPermutation rearranges s into the previous lexicographically smaller permutation and
+// reports whether there was one. If s is already the first permutation it is reset to
+// the last one, sorted descending, and false is returned.
+func PrevPermutation[E cmp.Ordered](s []E) bool {
+	i := len(s) - 2
+	for i >= 0 && s[i] <= s[i+1] {
+		i--
+	}
+	if i < 0 {
+		slices.Reverse(s)
+		return false
+	}
+	j := len(s) - 1
+	for s[j] >= s[i] {
+		j--
+	}
+	s[i], s[j] = s[j], s[i]
+	slices.Reverse(s[i+1:])
+	return true
+}
+
+// LexPermutations returns an iterator over all distinct permutations of s in lexicographic
+// order, starting from s sorted ascending. Repeated elements produce every arrangement of
+// the multiset once. s is not modified and every yielded slice is a new copy.
+//
+// Example:
+// for p := range LexPermutations([]int{1, 1, 2}) { ... }
+// // yields [1 1 2], [1 2 1], [2 1 1]
+func LexPermutations[E cmp.Ordered](s []E) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		perm := slices.Clone(s)
+		slices.Sort(perm)
+		for {
+			if !yield(slices.Clone(perm)) || !NextPermutation(perm) {
+				return
+			}
+		}
+	}
+}
+
+// PermutationRank returns the 0-based lexicographic rank of perm among all permutations of
+// its elements, which must be distinct. The rank is read from the Lehmer code of perm,
+// whose digits are counted with a Fenwick tree in O(n log n). It panics if the rank can
+// exceed int64 (more than 20 elements), use PermutationRankBig for those.
+func PermutationRank[E cmp.Ordered](perm []E) int64 {
+	if len(perm) > 20 {
+		panic("rank does not fit in int64, use PermutationRankBig")
+	}
+	res := int64(0)
+	for i, d := range lehmerCode(perm) {
+		res = res*int64(len(perm)-i) + int64(d)
+	}
+	return res
+}
+
+// PermutationRankBig returns the 0-based lexicographic rank of perm as a Big Integer, see PermutationRank
+func PermutationRankBig[E cmp.Ordered](perm []E) *big.Int {
+	res := new(big.Int)
+	for i, d := range lehmerCode(perm) {
+		res.Mul(res, big.NewInt(int64(len(perm)-i)))
+		res.Add(res, big.NewInt(int64(d)))
+	}
+	return res
+}
+
+// PermutationUnrank returns the permutation of elems with the 0-based lexicographic rank k.
+// The elements must be distinct and may be given in any order. k is written in the factorial
+// number system, and every digit picks one of the remaining elements.
+//
+// Example:
+// p := PermutationUnrank(999999, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+// // p == [2 7 8 3 9 1 5 4 6 0]
+func PermutationUnrank[E cmp.Ordered](k int64, elems []E) []E {
+	return PermutationUnrankBig(big.NewInt(k), elems)
+}
+
+// PermutationUnrankBig returns the permutation of elems with the 0-based lexicographic rank k,
+// see PermutationUnrank
+func PermutationUnrankBig[E cmp.Ordered](k *big.Int, elems []E) []E {
+	n := len(elems)
+	if k.Sign() < 0 || k.Cmp(FactorialBigInt(int64(n))) >= 0 {
+		panic("k is out of range")
+	}
+	// digits[i] is the factorial base digit with weight (n-1-i)!
+	digits := make([]int, n)
+	q, r := new(big.Int).Set(k), new(big.Int)
+	for j := 1; j <= n; j++ {
+		q.QuoRem(q, big.NewInt(int64(j)), r)
+		digits[n-j] = int(r.Int64())
+	}
+	rest := slices.Clone(elems)
+	slices.Sort(rest)
+	res := make([]E, 0, n)
+	for _, d := range digits {
+		res = append(res, rest[d])
+		rest = slices.Delete(rest, d, d+1)
+	}
+	return res
+}
+
+// MultisetPermutationRank returns the 0-based lexicographic rank of perm among the distinct
+// permutations of its elements, which may repeat. Every smaller element that could take a
+// position adds the number of arrangements of the remaining multiset to the rank.
+func MultisetPermutationRank[E cmp.Ordered](perm []E) *big.Int {
+	values, counts := multisetCounts(perm)
+	total := multinomialBig(counts)
+	res := new(big.Int)
+	tmp := new(big.Int)
+	for i, e := range perm {
+		remaining := big.NewInt(int64(len(perm) - i))
+		// values is ascending, so every value before e is smaller; total·c/remaining arrangements start with it
+		for j, v := range values {
+			tmp.Mul(total, big.NewInt(int64(counts[j])))
+			tmp.Quo(tmp, remaining)
+			if v == e {
+				total.Set(tmp)
+				counts[j]--
+				break
+			}
+			res.Add(res, tmp)
+		}
+	}
+	return res
+}
+
+// MultisetPermutationUnrank returns the distinct permutation of elems with the 0-based
+// lexicographic rank k, where elems may contain repeated elements
+func MultisetPermutationUnrank[E cmp.Ordered](k *big.Int, elems []E) []E {
+	values, counts := multisetCounts(elems)
+	total := multinomialBig(counts)
+	if k.Sign() < 0 || k.Cmp(total) >= 0 {
+		panic("k is out of range")
+	}
+	k = new(big.Int).Set(k)
+	res := make([]E, 0, len(elems))
+	tmp := new(big.Int)
+	for i := range elems {
+		remaining := big.NewInt(int64(len(elems) - i))
+		for j, v := range values {
+			if counts[j] == 0 {
+				continue
+			}
+			tmp.Mul(total, big.NewInt(int64(counts[j])))
+			tmp.Quo(tmp, remaining)
+			if k.Cmp(tmp) < 0 {
+				res = append(res, v)
+				total.Set(tmp)
+				counts[j]--
+				break
+			}
+			k.Sub(k, tmp)
+		}
+	}
+	return res
+}
+
+// lehmerCode returns for every position the number of later elements that are smaller
+func lehmerCode[E cmp.Ordered](perm []E) []int {
+	sorted := slices.Clone(perm)
+	slices.Sort(sorted)
+	// tree is a Fenwick tree over the sorted positions of the elements seen so far
+	tree := make([]int, len(perm)+1)
+	res := make([]int, len(perm))
+	for i := len(perm) - 1; i >= 0; i-- {
+		pos, _ := slices.BinarySearch(sorted, perm[i])
+		for j := pos; j > 0; j -= j & -j {
+			res[i] += tree[j]
+		}
+		for j := pos + 1; j < len(tree); j += j & -j {
+			tree[j]++
+		}
+	}
+	return res
+}
+
+// multisetCounts returns the distinct values of s in ascending order and how often each occurs
+func multisetCounts[E cmp.Ordered](s []E) (values []E, counts []int) {
+	sorted := slices.Clone(s)
+	slices.Sort(sorted)
+	for i, v := range sorted {
+		if i == 0 || v != sorted[i-1] {
+			values = append(values, v)
+			counts = append(counts, 0)
+		}
+		counts[len(counts)-1]++
+	}
+	return
+}
+
+// multinomialBig returns (Σ counts)! / Π counts[i]!
+func multinomialBig(counts []int) *big.Int {
+	res := big.NewInt(1)
+	n := int64(0)
+	for _, c := range counts {
+		// multiply by C(n+c, c) one factor at a time, which keeps every step exact
+		for i := int64(1); i <= int64(c); i++ {
+			n++
+			res.Mul(res, big.NewInt(n))
+			res.Quo(res, big.NewInt(i))
+		}
+	}
+	return res
+}
@@ -0,0 +1,127 @@
+package eulerlib
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestNextPermutation(t *testing.T) {
+	s := []int{1, 2, 3}
+	want := [][]int{{1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}}
+	for _, w := range want {
+		if !NextPermutation(s) || !reflect.DeepEqual(s, w) {
+			t.Fatalf("NextPermutation = %v, want %v", s, w)
+		}
+	}
+	if NextPermutation(s) || !reflect.DeepEqual(s, []int{1, 2, 3}) {
+		t.Errorf("NextPermutation of the last permutation = %v, want false and [1 2 3]", s)
+	}
+	if PrevPermutation(s) || !reflect.DeepEqual(s, []int{3, 2, 1}) {
+		t.Errorf("PrevPermutation of the first permutation = %v, want false and [3 2 1]", s)
+	}
+	for i := len(want) - 2; i >= 0; i-- {
+		if !PrevPermutation(s) || !reflect.DeepEqual(s, want[i]) {
+			t.Fatalf("PrevPermutation = %v, want %v", s, want[i])
+		}
+	}
+	empty := []string{}
+	if NextPermutation(empty) || PrevPermutation(empty) {
+		t.Errorf("an empty slice has no next or previous permutation")
+	}
+}
+
+func TestLexPermutations(t *testing.T) {
+	got := [][]int{}
+	for p := range LexPermutations([]int{2, 1, 1}) {
+		got = append(got, p)
+	}
+	want := [][]int{{1, 1, 2}, {1, 2, 1}, {2, 1, 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LexPermutations([2 1 1]) = %v, want %v", got, want)
+	}
+
+	count := 0
+	for p := range LexPermutations([]byte("mississippi")) {
+		count++
+		if count == 1 && string(p) != "iiiimppssss" {
+			t.Errorf("first permutation of mississippi = %s, want iiiimppssss", p)
+		}
+	}
+	if count != PermutationCount([]byte("mississippi")) {
+		t.Errorf("LexPermutations(mississippi) yielded %d permutations, want %d", count, PermutationCount([]byte("mississippi")))
+	}
+
+	// Project Euler 24: the millionth lexicographic permutation of 0..9
+	i := 0
+	for p := range LexPermutations([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		i++
+		if i == 1000000 {
+			if JoinSlice(p) != "2783915460" {
+				t.Errorf("millionth permutation of 0..9 = %s, want 2783915460", JoinSlice(p))
+			}
+			break
+		}
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	got := Permutations([]string{"b", "a", "b"})
+	want := [][]string{{"b", "a", "b"}, {"b", "b", "a"}, {"a", "b", "b"}, {"a", "b", "b"}, {"b", "b", "a"}, {"b", "a", "b"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Permutations([b a b]) = %v, want %v", got, want)
+	}
+	if got := Permutations([]bool{true, false, true, false}); len(got) != 24 {
+		t.Errorf("Permutations of 4 elements returned %d permutations, want 24", len(got))
+	}
+	if got := Permutations([]int{}); got == nil || len(got) != 0 {
+		t.Errorf("Permutations([]) = %v, want []", got)
+	}
+}
+
+func TestPermutationRank(t *testing.T) {
+	elems := []int{0, 1, 2, 3, 4}
+	rank := int64(0)
+	for p := range LexPermutations(elems) {
+		if got := PermutationRank(p); got != rank {
+			t.Fatalf("PermutationRank(%v) = %d, want %d", p, got, rank)
+		}
+		if got := PermutationUnrank(rank, []int{4, 2, 0, 1, 3}); !reflect.DeepEqual(got, p) {
+			t.Fatalf("PermutationUnrank(%d) = %v, want %v", rank, got, p)
+		}
+		rank++
+	}
+	if got := PermutationUnrank(999999, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}); JoinSlice(got) != "2783915460" {
+		t.Errorf("PermutationUnrank(999999, 0..9) = %v, want 2783915460", got)
+	}
+
+	// the last permutation of 30 elements has rank 30! - 1
+	long := make([]int, 30)
+	for i := range long {
+		long[i] = 29 - i
+	}
+	want := new(big.Int).Sub(FactorialBigInt(30), big.NewInt(1))
+	if got := PermutationRankBig(long); got.Cmp(want) != 0 {
+		t.Errorf("PermutationRankBig(30 descending) = %s, want %s", got, want)
+	}
+	if got := PermutationUnrankBig(want, Range(0, 30)); !reflect.DeepEqual(got, long) {
+		t.Errorf("PermutationUnrankBig(30! - 1) = %v, want %v", got, long)
+	}
+}
+
+func TestMultisetPermutationRank(t *testing.T) {
+	elems := []byte("aabbbc")
+	rank := int64(0)
+	for p := range LexPermutations(elems) {
+		if got := MultisetPermutationRank(p); got.Int64() != rank {
+			t.Fatalf("MultisetPermutationRank(%s) = %s, want %d", p, got, rank)
+		}
+		if got := MultisetPermutationUnrank(big.NewInt(rank), elems); string(got) != string(p) {
+			t.Fatalf("MultisetPermutationUnrank(%d) = %s, want %s", rank, got, p)
+		}
+		rank++
+	}
+	if rank != int64(PermutationCount(elems)) {
+		t.Errorf("aabbbc has %d distinct permutations, want %d", rank, PermutationCount(elems))
+	}
+}
@@ -0,0 +1,368 @@
+package eulerlib
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Rational is an exact fraction num/den stored in lowest terms with den > 0.
+// As long as the numerator and denominator fit in E the arithmetic works on E directly;
+// once an operation would overflow E the value falls back to a *big.Rat, and it moves
+// back to E as soon as a result fits again. Rationals are immutable values and the
+// zero value is 0.
+type Rational[E Integer] struct {
+	num, den E
+	rat      *big.Rat
+}
+
+// NewRational returns num/den in lowest terms. It panics if den is 0.
+func NewRational[E Integer](num, den E) Rational[E] {
+	if den == 0 {
+		panic("denominator must not be zero")
+	}
+	if r, ok := newSmallRational(num, den); ok {
+		return r
+	}
+	return ratToRational[E](new(big.Rat).SetFrac(integerToBig(num), integerToBig(den)))
+}
+
+// RationalFromInt returns the rational n/1
+func RationalFromInt[E Integer](n E) Rational[E] {
+	return Rational[E]{num: n, den: 1}
+}
+
+// RationalFromBig returns the value of x as a Rational
+func RationalFromBig[E Integer](x *big.Rat) Rational[E] {
+	return ratToRational[E](new(big.Rat).Set(x))
+}
+
+// IsBig reports whether the value no longer fits in E and is backed by a *big.Rat
+func (r Rational[E]) IsBig() bool {
+	return r.rat != nil
+}
+
+// Small returns the numerator and denominator as E, and false if they do not fit
+func (r Rational[E]) Small() (num, den E, ok bool) {
+	if r.rat != nil {
+		return 0, 0, false
+	}
+	num, den = r.parts()
+	return num, den, true
+}
+
+// Num returns the numerator as a Big Integer
+func (r Rational[E]) Num() *big.Int {
+	return new(big.Int).Set(r.Big().Num())
+}
+
+// Den returns the denominator as a Big Integer, which is always positive
+func (r Rational[E]) Den() *big.Int {
+	return new(big.Int).Set(r.Big().Denom())
+}
+
+// Big returns the value as a new *big.Rat
+func (r Rational[E]) Big() *big.Rat {
+	if r.rat != nil {
+		return new(big.Rat).Set(r.rat)
+	}
+	num, den := r.parts()
+	return new(big.Rat).SetFrac(integerToBig(num), integerToBig(den))
+}
+
+// Add returns r + s
+func (r Rational[E]) Add(s Rational[E]) Rational[E] {
+	if r.rat == nil && s.rat == nil {
+		a, b := r.parts()
+		c, d := s.parts()
+		g := gcd(b, d)
+		x, ok1 := mulChecked(a, d/g)
+		y, ok2 := mulChecked(c, b/g)
+		num, ok3 := addChecked(x, y)
+		den, ok4 := mulChecked(b, d/g)
+		if ok1 && ok2 && ok3 && ok4 {
+			if res, ok := newSmallRational(num, den); ok {
+				return res
+			}
+		}
+	}
+	return ratToRational[E](new(big.Rat).Add(r.Big(), s.Big()))
+}
+
+// Sub returns r - s
+func (r Rational[E]) Sub(s Rational[E]) Rational[E] {
+	return r.Add(s.Neg())
+}
+
+// Mul returns r · s
+func (r Rational[E]) Mul(s Rational[E]) Rational[E] {
+	if r.rat == nil && s.rat == nil {
+		a, b := r.parts()
+		c, d := s.parts()
+		// cross-cancel first so that the products stay as small as possible
+		g1, g2 := gcdAbs(a, d), gcdAbs(c, b)
+		num, ok1 := mulChecked(a/g1, c/g2)
+		den, ok2 := mulChecked(b/g2, d/g1)
+		if ok1 && ok2 {
+			if res, ok := newSmallRational(num, den); ok {
+				return res
+			}
+		}
+	}
+	return ratToRational[E](new(big.Rat).Mul(r.Big(), s.Big()))
+}
+
+// Quo returns r / s. It panics if s is 0.
+func (r Rational[E]) Quo(s Rational[E]) Rational[E] {
+	return r.Mul(s.Inv())
+}
+
+// Neg returns -r
+func (r Rational[E]) Neg() Rational[E] {
+	if r.rat == nil {
+		num, den := r.parts()
+		if n, ok := negChecked(num); ok {
+			return Rational[E]{num: n, den: den}
+		}
+	}
+	return ratToRational[E](new(big.Rat).Neg(r.Big()))
+}
+
+// Inv returns 1/r. It panics if r is 0.
+func (r Rational[E]) Inv() Rational[E] {
+	if r.Sign() == 0 {
+		panic("division by zero")
+	}
+	if r.rat == nil {
+		num, den := r.parts()
+		if res, ok := newSmallRational(den, num); ok {
+			return res
+		}
+	}
+	return ratToRational[E](new(big.Rat).Inv(r.Big()))
+}
+
+// Abs returns |r|
+func (r Rational[E]) Abs() Rational[E] {
+	if r.Sign() < 0 {
+		return r.Neg()
+	}
+	return r
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of r
+func (r Rational[E]) Sign() int {
+	if r.rat != nil {
+		return r.rat.Sign()
+	}
+	switch {
+	case r.num < 0:
+		return -1
+	case r.num > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Cmp returns -1, 0 or 1 depending on whether r is less than, equal to or greater than s
+func (r Rational[E]) Cmp(s Rational[E]) int {
+	if r.rat == nil && s.rat == nil {
+		a, b := r.parts()
+		c, d := s.parts()
+		x, ok1 := mulChecked(a, d)
+		y, ok2 := mulChecked(c, b)
+		if ok1 && ok2 {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+	return r.Big().Cmp(s.Big())
+}
+
+// Equal reports whether r and s are the same number
+func (r Rational[E]) Equal(s Rational[E]) bool {
+	return r.Cmp(s) == 0
+}
+
+// IsInt reports whether the denominator is 1
+func (r Rational[E]) IsInt() bool {
+	if r.rat != nil {
+		return r.rat.IsInt()
+	}
+	_, den := r.parts()
+	return den == 1
+}
+
+// Floor returns the largest integer <= r
+func (r Rational[E]) Floor() *big.Int {
+	// DivMod rounds towards negative infinity for a positive divisor
+	q := new(big.Int)
+	q.DivMod(r.Num(), r.Den(), new(big.Int))
+	return q
+}
+
+// Ceil returns the smallest integer >= r
+func (r Rational[E]) Ceil() *big.Int {
+	q := r.Floor()
+	if !r.IsInt() {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
+}
+
+// Float64 returns the nearest float64 to r
+func (r Rational[E]) Float64() float64 {
+	f, _ := r.Big().Float64()
+	return f
+}
+
+// String returns r as "num/den", or just "num" for integers
+func (r Rational[E]) String() string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	return r.Num().String() + "/" + r.Den().String()
+}
+
+// MixedString returns r as a mixed number such as "-2 1/3", with the whole part
+// truncated towards zero. Integers and proper fractions have no space.
+func (r Rational[E]) MixedString() string {
+	whole, rem := new(big.Int).QuoRem(r.Num(), r.Den(), new(big.Int))
+	switch {
+	case rem.Sign() == 0:
+		return whole.String()
+	case whole.Sign() == 0:
+		return r.String()
+	}
+	return whole.String() + " " + rem.Abs(rem).String() + "/" + r.Den().String()
+}
+
+// Decimal returns the exact decimal expansion of r, with the repeating part in parentheses.
+// The length of the period is at most the denominator, which should be kept in mind for large denominators.
+//
+// Example:
+// s := NewRational(-7, 12).Decimal()
+// // s == "-0.58(3)"
+func (r Rational[E]) Decimal() string {
+	num, den := r.Num(), r.Den()
+	var sb strings.Builder
+	if num.Sign() < 0 {
+		sb.WriteByte('-')
+		num.Neg(num)
+	}
+	whole, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	sb.WriteString(whole.String())
+	if rem.Sign() == 0 {
+		return sb.String()
+	}
+	sb.WriteByte('.')
+	digits := []byte{}
+	seen := make(map[string]int)
+	ten, digit := big.NewInt(10), new(big.Int)
+	for rem.Sign() != 0 {
+		key := rem.String()
+		if i, ok := seen[key]; ok {
+			sb.Write(digits[:i])
+			sb.WriteByte('(')
+			sb.Write(digits[i:])
+			sb.WriteByte(')')
+			return sb.String()
+		}
+		seen[key] = len(digits)
+		rem.Mul(rem, ten)
+		digit.QuoRem(rem, den, rem)
+		digits = append(digits, byte('0'+digit.Int64()))
+	}
+	sb.Write(digits)
+	return sb.String()
+}
+
+// parts returns the numerator and denominator of a small rational, mapping the zero value to 0/1
+func (r Rational[E]) parts() (E, E) {
+	if r.den == 0 {
+		return 0, 1
+	}
+	return r.num, r.den
+}
+
+// newSmallRational returns num/den in lowest terms with a positive denominator,
+// and false if that is not representable in E
+func newSmallRational[E Integer](num, den E) (Rational[E], bool) {
+	if den < 0 {
+		var ok1, ok2 bool
+		num, ok1 = negChecked(num)
+		den, ok2 = negChecked(den)
+		if !ok1 || !ok2 {
+			return Rational[E]{}, false
+		}
+	}
+	g := gcdAbs(num, den)
+	return Rational[E]{num: num / g, den: den / g}, true
+}
+
+// ratToRational stores x in E if possible, taking ownership of x otherwise
+func ratToRational[E Integer](x *big.Rat) Rational[E] {
+	num, ok1 := bigToInteger[E](x.Num())
+	den, ok2 := bigToInteger[E](x.Denom())
+	if ok1 && ok2 {
+		return Rational[E]{num: num, den: den}
+	}
+	return Rational[E]{rat: x}
+}
+
+// integerToBig converts any Integer to a Big Integer
+func integerToBig[E Integer](n E) *big.Int {
+	if n < 0 {
+		return big.NewInt(int64(n))
+	}
+	return new(big.Int).SetUint64(uint64(n))
+}
+
+// bigToInteger converts b to E, and reports false if it does not fit
+func bigToInteger[E Integer](b *big.Int) (E, bool) {
+	if b.IsInt64() {
+		v := b.Int64()
+		return E(v), int64(E(v)) == v && (E(v) < 0) == (v < 0)
+	}
+	if b.IsUint64() {
+		v := b.Uint64()
+		return E(v), uint64(E(v)) == v && E(v) >= 0
+	}
+	return 0, false
+}
+
+// gcdAbs returns the non-negative greatest common divisor of a and b
+func gcdAbs[E Integer](a, b E) E {
+	g := gcd(a, b)
+	if g < 0 {
+		g = -g
+	}
+	return g
+}
+
+// mulChecked returns a·b and whether it did not overflow E
+func mulChecked[E Integer](a, b E) (E, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	return c, c/b == a && c/a == b
+}
+
+// addChecked returns a+b and whether it did not overflow E
+func addChecked[E Integer](a, b E) (E, bool) {
+	c := a + b
+	return c, (b >= 0) == (c >= a)
+}
+
+// negChecked returns -a and whether it is representable in E
+func negChecked[E Integer](a E) (E, bool) {
+	c := -a
+	return c, a == 0 || (c < 0) != (a < 0)
+}
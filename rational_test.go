@@ -0,0 +1,133 @@
+package eulerlib
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestNewRational(t *testing.T) {
+	testCases := []struct {
+		num, den int64
+		want     string
+	}{
+		{6, 8, "3/4"},
+		{-6, 8, "-3/4"},
+		{6, -8, "-3/4"},
+		{-6, -8, "3/4"},
+		{0, -5, "0"},
+		{10, 5, "2"},
+		{math.MinInt64, -1, "9223372036854775808"},
+		{math.MinInt64, 2, "-4611686018427387904"},
+	}
+	for _, tc := range testCases {
+		if got := NewRational(tc.num, tc.den).String(); got != tc.want {
+			t.Errorf("NewRational(%d, %d) = %s, want %s", tc.num, tc.den, got, tc.want)
+		}
+	}
+	if !NewRational(int64(math.MinInt64), -1).IsBig() {
+		t.Errorf("NewRational(MinInt64, -1) should fall back to big.Rat")
+	}
+	var zero Rational[int]
+	if zero.String() != "0" || zero.Add(NewRational(1, 2)).String() != "1/2" {
+		t.Errorf("the zero value of Rational should be 0")
+	}
+}
+
+func TestRationalArithmetic(t *testing.T) {
+	a, b := NewRational(1, 6), NewRational(-3, 4)
+	testCases := []struct {
+		name string
+		got  Rational[int]
+		want string
+	}{
+		{"a+b", a.Add(b), "-7/12"},
+		{"a-b", a.Sub(b), "11/12"},
+		{"a*b", a.Mul(b), "-1/8"},
+		{"a/b", a.Quo(b), "-2/9"},
+		{"-b", b.Neg(), "3/4"},
+		{"1/b", b.Inv(), "-4/3"},
+		{"|b|", b.Abs(), "3/4"},
+	}
+	for _, tc := range testCases {
+		if tc.got.String() != tc.want {
+			t.Errorf("%s = %s, want %s", tc.name, tc.got, tc.want)
+		}
+	}
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 || a.Cmp(NewRational(2, 12)) != 0 || !a.Equal(NewRational(-1, -6)) {
+		t.Errorf("Cmp(%s, %s) is inconsistent", a, b)
+	}
+
+	// harmonic numbers overflow int64 quickly, the result must still be exact
+	h := Rational[int64]{}
+	want := new(big.Rat)
+	for k := int64(1); k <= 60; k++ {
+		h = h.Add(NewRational(1, k))
+		want.Add(want, big.NewRat(1, k))
+	}
+	if !h.IsBig() || h.Big().Cmp(want) != 0 {
+		t.Errorf("H(60) = %s, want %s", h, want.RatString())
+	}
+	// and subtracting everything again must move back to int64
+	for k := int64(1); k <= 60; k++ {
+		h = h.Sub(NewRational(1, k))
+	}
+	if h.IsBig() || h.Sign() != 0 {
+		t.Errorf("H(60) - H(60) = %s (big: %t), want small 0", h, h.IsBig())
+	}
+
+	// unsigned rationals fall back to big.Rat for negative values
+	u := NewRational[uint8](1, 2).Sub(NewRational[uint8](3, 4))
+	if !u.IsBig() || u.String() != "-1/4" {
+		t.Errorf("uint8 1/2 - 3/4 = %s (big: %t), want big -1/4", u, u.IsBig())
+	}
+	if v := NewRational[uint8](200, 3).Mul(NewRational[uint8](3, 2)); v.IsBig() || v.String() != "100" {
+		t.Errorf("uint8 200/3 * 3/2 = %s (big: %t), want small 100", v, v.IsBig())
+	}
+}
+
+func TestRationalFloorCeil(t *testing.T) {
+	testCases := []struct {
+		num, den    int
+		floor, ceil int64
+	}{
+		{7, 2, 3, 4},
+		{-7, 2, -4, -3},
+		{6, 3, 2, 2},
+		{-1, 3, -1, 0},
+	}
+	for _, tc := range testCases {
+		r := NewRational(tc.num, tc.den)
+		if f, c := r.Floor().Int64(), r.Ceil().Int64(); f != tc.floor || c != tc.ceil {
+			t.Errorf("%s: Floor = %d, Ceil = %d, want %d, %d", r, f, c, tc.floor, tc.ceil)
+		}
+	}
+}
+
+func TestRationalStrings(t *testing.T) {
+	testCases := []struct {
+		num, den       int
+		mixed, decimal string
+	}{
+		{7, 3, "2 1/3", "2.(3)"},
+		{-7, 3, "-2 1/3", "-2.(3)"},
+		{1, 3, "1/3", "0.(3)"},
+		{-7, 12, "-7/12", "-0.58(3)"},
+		{1, 7, "1/7", "0.(142857)"},
+		{5, 4, "1 1/4", "1.25"},
+		{4, 2, "2", "2"},
+		{1, 6, "1/6", "0.1(6)"},
+	}
+	for _, tc := range testCases {
+		r := NewRational(tc.num, tc.den)
+		if got := r.MixedString(); got != tc.mixed {
+			t.Errorf("%s.MixedString() = %s, want %s", r, got, tc.mixed)
+		}
+		if got := r.Decimal(); got != tc.decimal {
+			t.Errorf("%s.Decimal() = %s, want %s", r, got, tc.decimal)
+		}
+	}
+	if f := NewRational(1, 8).Float64(); f != 0.125 {
+		t.Errorf("NewRational(1, 8).Float64() = %v, want 0.125", f)
+	}
+}
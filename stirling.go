@@ -0,0 +1,283 @@
+package eulerlib
+
+import (
+	"iter"
+	"math/big"
+)
+
+// The Stirling numbers of the first kind here are the unsigned ones c(n, k), the number of
+// permutations of n elements with k cycles. The signed value is (-1)^(n-k)·c(n, k).
+// The Stirling numbers of the second kind S(n, k) count the partitions of an n-set into
+// k non-empty blocks. Both tables are triangles whose row n holds the values for k = 0..n.
+
+// StirlingFirstTable returns the rows 0..n of the unsigned Stirling numbers of the first kind,
+// computed with c(n+1, k) = n·c(n, k) + c(n, k-1)
+func StirlingFirstTable(n int) [][]*big.Int {
+	return triangle(n, stirlingCoef(true))
+}
+
+// StirlingSecondTable returns the rows 0..n of the Stirling numbers of the second kind,
+// computed with S(n+1, k) = k·S(n, k) + S(n, k-1)
+func StirlingSecondTable(n int) [][]*big.Int {
+	return triangle(n, stirlingCoef(false))
+}
+
+// StirlingFirst returns the unsigned Stirling number of the first kind c(n, k),
+// and 0 if k is not in [0, n]
+//
+// Example:
+// c := StirlingFirst(5, 2)
+// // c == 50
+func StirlingFirst(n, k int) *big.Int {
+	return triangleEntry(n, k, stirlingCoef(true))
+}
+
+// StirlingSecond returns the Stirling number of the second kind S(n, k),
+// and 0 if k is not in [0, n]
+//
+// Example:
+// s := StirlingSecond(5, 2)
+// // s == 15
+func StirlingSecond(n, k int) *big.Int {
+	return triangleEntry(n, k, stirlingCoef(false))
+}
+
+// StirlingFirstTableMod returns the rows 0..n of the unsigned Stirling numbers of the first kind modulo m
+func StirlingFirstTableMod(n int, m int64) [][]int64 {
+	return triangleMod(n, m, stirlingCoef(true))
+}
+
+// StirlingSecondTableMod returns the rows 0..n of the Stirling numbers of the second kind modulo m
+func StirlingSecondTableMod(n int, m int64) [][]int64 {
+	return triangleMod(n, m, stirlingCoef(false))
+}
+
+// StirlingFirstMod returns c(n, k) mod m in O(n·k) time and O(k) space
+func StirlingFirstMod(n, k int, m int64) int64 {
+	return triangleEntryMod(n, k, m, stirlingCoef(true))
+}
+
+// StirlingSecondMod returns S(n, k) mod m in O(n·k) time and O(k) space
+func StirlingSecondMod(n, k int, m int64) int64 {
+	return triangleEntryMod(n, k, m, stirlingCoef(false))
+}
+
+// BellNumbers returns the Bell numbers B(0), ..., B(n), the number of partitions of a set
+// of each size, built with the Bell triangle in O(n²) additions
+//
+// Example:
+// b := BellNumbers(5)
+// // b == [1 1 2 5 15 52]
+func BellNumbers(n int) []*big.Int {
+	if n < 0 {
+		return nil
+	}
+	res := []*big.Int{big.NewInt(1)}
+	// each row of the triangle starts with the last entry of the previous one
+	row := []*big.Int{big.NewInt(1)}
+	for i := 1; i <= n; i++ {
+		next := make([]*big.Int, i+1)
+		next[0] = row[i-1]
+		for j := 1; j <= i; j++ {
+			next[j] = new(big.Int).Add(next[j-1], row[j-1])
+		}
+		row = next
+		res = append(res, row[0])
+	}
+	return res
+}
+
+// BellNumber returns the Bell number B(n)
+func BellNumber(n int) *big.Int {
+	if n < 0 {
+		panic("n must not be negative")
+	}
+	return BellNumbers(n)[n]
+}
+
+// BellNumberMod returns B(n) mod m, using the Bell triangle in O(n²) time and O(n) space
+func BellNumberMod(n int, m int64) int64 {
+	if n < 0 || m < 1 {
+		panic("BellNumberMod requires n >= 0 and m >= 1")
+	}
+	row := []int64{1 % m}
+	for i := 1; i <= n; i++ {
+		next := make([]int64, i+1)
+		next[0] = row[i-1]
+		for j := 1; j <= i; j++ {
+			next[j] = addMod(next[j-1], row[j-1], m)
+		}
+		row = next
+	}
+	return row[0]
+}
+
+// RestrictedGrowthStrings returns an iterator over all restricted growth strings of length n
+// in lexicographic order: a[0] = 0 and a[i] <= 1 + max(a[0], ..., a[i-1]). Each one describes
+// a set partition in which element i belongs to block a[i], so there are B(n) of them.
+//
+// Example:
+// for a := range RestrictedGrowthStrings(3) { ... }
+// // yields [0 0 0], [0 0 1], [0 1 0], [0 1 1], [0 1 2]
+func RestrictedGrowthStrings(n int) iter.Seq[[]int] {
+	return clones(RestrictedGrowthStringsReuse(n))
+}
+
+// RestrictedGrowthStringsReuse is like RestrictedGrowthStrings, but yields the same buffer
+// at every step, which is only valid until the next one
+func RestrictedGrowthStringsReuse(n int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if n < 0 {
+			return
+		}
+		a := make([]int, n)
+		// prefixMax[i] is the maximum of a[0..i]
+		prefixMax := make([]int, n)
+		for yield(a) {
+			i := n - 1
+			for i > 0 && a[i] > prefixMax[i-1] {
+				i--
+			}
+			if i <= 0 {
+				return
+			}
+			a[i]++
+			prefixMax[i] = max(prefixMax[i-1], a[i])
+			for j := i + 1; j < n; j++ {
+				a[j] = 0
+				prefixMax[j] = prefixMax[i]
+			}
+		}
+	}
+}
+
+// SetPartitions returns an iterator over all partitions of set into non-empty blocks, in the
+// order of their restricted growth strings. Blocks are ordered by their first element and keep
+// the order of set. Every yielded partition is newly allocated.
+//
+// Example:
+// for p := range SetPartitions([]int{1, 2, 3}) { ... }
+// // yields [[1 2 3]], [[1 2] [3]], [[1 3] [2]], [[1] [2 3]], [[1] [2] [3]]
+func SetPartitions[E any](set []E) iter.Seq[[][]E] {
+	return func(yield func([][]E) bool) {
+		for a := range RestrictedGrowthStringsReuse(len(set)) {
+			var blocks [][]E
+			for i, b := range a {
+				if b == len(blocks) {
+					blocks = append(blocks, nil)
+				}
+				blocks[b] = append(blocks[b], set[i])
+			}
+			if !yield(blocks) {
+				return
+			}
+		}
+	}
+}
+
+// stirlingCoef returns the coefficients of the recurrence of either kind, see triangle
+func stirlingCoef(first bool) func(i, k int) (int64, int64) {
+	if first {
+		return func(i, k int) (int64, int64) { return int64(i), 1 }
+	}
+	return func(i, k int) (int64, int64) { return int64(k), 1 }
+}
+
+// triangle returns the rows 0..n of the triangle T(0, 0) = 1,
+// T(i+1, k) = a·T(i, k) + b·T(i, k-1) where a, b = coef(i, k), row i holding k = 0..i
+func triangle(n int, coef func(i, k int) (int64, int64)) [][]*big.Int {
+	if n < 0 {
+		return nil
+	}
+	t := make([][]*big.Int, n+1)
+	t[0] = []*big.Int{big.NewInt(1)}
+	tmp := new(big.Int)
+	for i := range n {
+		row := make([]*big.Int, i+2)
+		for k := range row {
+			a, b := coef(i, k)
+			row[k] = new(big.Int)
+			if k <= i {
+				row[k].Mul(t[i][k], big.NewInt(a))
+			}
+			if k > 0 {
+				row[k].Add(row[k], tmp.Mul(t[i][k-1], big.NewInt(b)))
+			}
+		}
+		t[i+1] = row
+	}
+	return t
+}
+
+// triangleEntry returns T(n, k) of the triangle described by coef, rolling a single
+// row of length k+1 forward to row n, and 0 if k is not in [0, n]
+func triangleEntry(n, k int, coef func(i, k int) (int64, int64)) *big.Int {
+	if k < 0 || k > n {
+		return new(big.Int)
+	}
+	row := make([]*big.Int, k+1)
+	for j := range row {
+		row[j] = new(big.Int)
+	}
+	row[0].SetInt64(1)
+	tmp := new(big.Int)
+	for i := range n {
+		// go downwards so that row[j-1] still holds the value of row i
+		for j := min(i+1, k); j >= 0; j-- {
+			a, b := coef(i, j)
+			row[j].Mul(row[j], big.NewInt(a))
+			if j > 0 {
+				row[j].Add(row[j], tmp.Mul(row[j-1], big.NewInt(b)))
+			}
+		}
+	}
+	return row[k]
+}
+
+// triangleMod is triangle modulo m
+func triangleMod(n int, m int64, coef func(i, k int) (int64, int64)) [][]int64 {
+	if m < 1 {
+		panic("modulus must be positive")
+	}
+	if n < 0 {
+		return nil
+	}
+	t := make([][]int64, n+1)
+	t[0] = []int64{1 % m}
+	for i := range n {
+		row := make([]int64, i+2)
+		for k := range row {
+			a, b := coef(i, k)
+			if k <= i {
+				row[k] = mulMod(t[i][k], normMod(a, m), m)
+			}
+			if k > 0 {
+				row[k] = addMod(row[k], mulMod(t[i][k-1], normMod(b, m), m), m)
+			}
+		}
+		t[i+1] = row
+	}
+	return t
+}
+
+// triangleEntryMod is triangleEntry modulo m
+func triangleEntryMod(n, k int, m int64, coef func(i, k int) (int64, int64)) int64 {
+	if m < 1 {
+		panic("modulus must be positive")
+	}
+	if k < 0 || k > n {
+		return 0
+	}
+	row := make([]int64, k+1)
+	row[0] = 1 % m
+	for i := range n {
+		for j := min(i+1, k); j >= 0; j-- {
+			a, b := coef(i, j)
+			row[j] = mulMod(row[j], normMod(a, m), m)
+			if j > 0 {
+				row[j] = addMod(row[j], mulMod(row[j-1], normMod(b, m), m), m)
+			}
+		}
+	}
+	return row[k]
+}
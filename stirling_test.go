@@ -0,0 +1,123 @@
+package eulerlib
+
+import (
+	"math/big"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestStirlingTables(t *testing.T) {
+	// OEIS A132393 and A008277, rows 0..5
+	wantFirst := [][]int64{{1}, {0, 1}, {0, 1, 1}, {0, 2, 3, 1}, {0, 6, 11, 6, 1}, {0, 24, 50, 35, 10, 1}}
+	wantSecond := [][]int64{{1}, {0, 1}, {0, 1, 1}, {0, 1, 3, 1}, {0, 1, 7, 6, 1}, {0, 1, 15, 25, 10, 1}}
+	first, second := StirlingFirstTable(5), StirlingSecondTable(5)
+	firstMod, secondMod := StirlingFirstTableMod(5, 1000), StirlingSecondTableMod(5, 1000)
+	for n := range wantFirst {
+		for k := range wantFirst[n] {
+			if first[n][k].Int64() != wantFirst[n][k] || firstMod[n][k] != wantFirst[n][k] {
+				t.Errorf("StirlingFirstTable(5)[%d][%d] == %s (mod: %d), want %d", n, k, first[n][k], firstMod[n][k], wantFirst[n][k])
+			}
+			if second[n][k].Int64() != wantSecond[n][k] || secondMod[n][k] != wantSecond[n][k] {
+				t.Errorf("StirlingSecondTable(5)[%d][%d] == %s (mod: %d), want %d", n, k, second[n][k], secondMod[n][k], wantSecond[n][k])
+			}
+		}
+	}
+}
+
+func TestStirlingSingle(t *testing.T) {
+	first, second := StirlingFirstTable(40), StirlingSecondTable(40)
+	const m = 1000000007
+	for n := range 41 {
+		for k := -1; k <= n+1; k++ {
+			wantFirst, wantSecond := new(big.Int), new(big.Int)
+			if k >= 0 && k <= n {
+				wantFirst, wantSecond = first[n][k], second[n][k]
+			}
+			if got := StirlingFirst(n, k); got.Cmp(wantFirst) != 0 {
+				t.Errorf("StirlingFirst(%d, %d) == %s, want %s", n, k, got, wantFirst)
+			}
+			if got := StirlingSecond(n, k); got.Cmp(wantSecond) != 0 {
+				t.Errorf("StirlingSecond(%d, %d) == %s, want %s", n, k, got, wantSecond)
+			}
+			if got, want := StirlingFirstMod(n, k, m), new(big.Int).Mod(wantFirst, big.NewInt(m)).Int64(); got != want {
+				t.Errorf("StirlingFirstMod(%d, %d, %d) == %d, want %d", n, k, m, got, want)
+			}
+			if got, want := StirlingSecondMod(n, k, m), new(big.Int).Mod(wantSecond, big.NewInt(m)).Int64(); got != want {
+				t.Errorf("StirlingSecondMod(%d, %d, %d) == %d, want %d", n, k, m, got, want)
+			}
+		}
+	}
+	// the first kind sums to n! over a row, the second kind to B(n)
+	sum := new(big.Int)
+	for _, c := range first[20] {
+		sum.Add(sum, c)
+	}
+	if sum.Cmp(FactorialBigInt(20)) != 0 {
+		t.Errorf("sum of StirlingFirstTable(40)[20] == %s, want 20!", sum)
+	}
+}
+
+func TestBellNumbers(t *testing.T) {
+	// OEIS A000110
+	want := []int64{1, 1, 2, 5, 15, 52, 203, 877, 4140, 21147, 115975, 678570, 4213597}
+	for n, b := range BellNumbers(len(want) - 1) {
+		if b.Int64() != want[n] {
+			t.Errorf("BellNumbers()[%d] == %s, want %d", n, b, want[n])
+		}
+	}
+	second := StirlingSecondTable(60)
+	for _, n := range []int{0, 1, 30, 60} {
+		sum := new(big.Int)
+		for _, s := range second[n] {
+			sum.Add(sum, s)
+		}
+		if got := BellNumber(n); got.Cmp(sum) != 0 {
+			t.Errorf("BellNumber(%d) == %s, want %s", n, got, sum)
+		}
+		if got, want := BellNumberMod(n, 1000003), new(big.Int).Mod(sum, big.NewInt(1000003)).Int64(); got != want {
+			t.Errorf("BellNumberMod(%d, 1000003) == %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestSetPartitions(t *testing.T) {
+	got := slices.Collect(SetPartitions([]string{"a", "b", "c"}))
+	want := [][][]string{
+		{{"a", "b", "c"}},
+		{{"a", "b"}, {"c"}},
+		{{"a", "c"}, {"b"}},
+		{{"a"}, {"b", "c"}},
+		{{"a"}, {"b"}, {"c"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SetPartitions(abc) == %v, want %v", got, want)
+	}
+
+	// counting by the number of blocks gives the Stirling numbers of the second kind
+	counts := make([]int64, 9)
+	for p := range SetPartitions(Range(0, 8)) {
+		counts[len(p)]++
+	}
+	for k, c := range counts {
+		if want := StirlingSecond(8, k).Int64(); c != want {
+			t.Errorf("SetPartitions(0..7) has %d partitions into %d blocks, want %d", c, k, want)
+		}
+	}
+
+	if got := slices.Collect(RestrictedGrowthStrings(0)); !reflect.DeepEqual(got, [][]int{{}}) {
+		t.Errorf("RestrictedGrowthStrings(0) == %v, want [[]]", got)
+	}
+	strings := slices.Collect(RestrictedGrowthStrings(3))
+	wantStrings := [][]int{{0, 0, 0}, {0, 0, 1}, {0, 1, 0}, {0, 1, 1}, {0, 1, 2}}
+	if !reflect.DeepEqual(strings, wantStrings) {
+		t.Errorf("RestrictedGrowthStrings(3) == %v, want %v", strings, wantStrings)
+	}
+	i := 0
+	for a := range RestrictedGrowthStringsReuse(3) {
+		if !slices.Equal(a, wantStrings[i]) {
+			t.Errorf("RestrictedGrowthStringsReuse(3) yielded %v at %d, want %v", a, i, wantStrings[i])
+		}
+		i++
+	}
+}
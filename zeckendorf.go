@@ -0,0 +1,131 @@
+package eulerlib
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// Zeckendorf returns the unique set of non-consecutive Fibonacci numbers summing to n,
+// in descending order, found greedily. Returns an empty slice for n <= 0.
+//
+// Example:
+// z := Zeckendorf(100)
+// // z == [89 8 3]
+func Zeckendorf(n int64) []int64 {
+	res := []int64{}
+	fibs := FibonacciUpTo(n)
+	for _, k := range ZeckendorfIndices(n) {
+		res = append(res, fibs[k])
+	}
+	return res
+}
+
+// ZeckendorfIndices returns the indices k >= 2 of the Fibonacci numbers F(k) in the
+// Zeckendorf representation of n, in descending order
+func ZeckendorfIndices(n int64) []int {
+	res := []int{}
+	fibs := FibonacciUpTo(n)
+	for k := len(fibs) - 1; k >= 2 && n > 0; k-- {
+		if fibs[k] <= n {
+			res = append(res, k)
+			n -= fibs[k]
+			k--
+		}
+	}
+	return res
+}
+
+// FromZeckendorfIndices is the inverse of ZeckendorfIndices: it returns the sum of F(k)
+// over the given indices
+func FromZeckendorfIndices(indices []int) (res int64) {
+	if len(indices) == 0 {
+		return
+	}
+	fibs := FibonacciN(Max(indices...) + 1)
+	for _, k := range indices {
+		res += fibs[k]
+	}
+	return
+}
+
+// ZeckendorfTermCount returns z(n), the number of terms in the Zeckendorf representation of n
+func ZeckendorfTermCount(n int64) int {
+	return len(ZeckendorfIndices(n))
+}
+
+// ZeckendorfTermCountSum returns Σ z(m) for 0 <= m < n in O(log n) time.
+// It uses Σ_{m<F(k+1)} z(m) = Σ_{m<F(k)} z(m) + Σ_{m<F(k-1)} z(m) + F(k-1),
+// and splits n at its largest Fibonacci number F(k) <= n, since every m in
+// [F(k), n) is F(k) plus the representation of m - F(k).
+func ZeckendorfTermCountSum(n int64) (res int64) {
+	fibs := FibonacciUpTo(n)
+	// sums[k] = Σ z(m) for m < F(k)
+	sums := make([]int64, len(fibs)+1)
+	for k := 3; k < len(sums); k++ {
+		sums[k] = sums[k-1] + sums[k-2] + fibs[k-2]
+	}
+	for k := len(fibs) - 1; k >= 2 && n > 0; k-- {
+		if fibs[k] <= n {
+			res += sums[k] + n - fibs[k]
+			n -= fibs[k]
+		}
+	}
+	return
+}
+
+// FibonacciCode returns the Fibonacci coding of n >= 1: the Zeckendorf digits for
+// F(2), F(3), ... from least to most significant, followed by an extra 1,
+// so that every codeword ends in "11"
+//
+// Example:
+// s := FibonacciCode(11)
+// // s == "001011"
+func FibonacciCode(n int64) string {
+	if n < 1 {
+		panic("n must be positive")
+	}
+	indices := ZeckendorfIndices(n)
+	digits := []byte(strings.Repeat("0", indices[0]))
+	for _, k := range indices {
+		digits[k-2] = '1'
+	}
+	digits[len(digits)-1] = '1'
+	return string(digits)
+}
+
+// DecodeFibonacciCode decodes a concatenation of Fibonacci codewords, see FibonacciCode.
+// It returns an error if s contains anything but 0 and 1, ends in an incomplete codeword,
+// or contains a codeword whose value does not fit in an int64.
+func DecodeFibonacciCode(s string) ([]int64, error) {
+	res := []int64{}
+	// f and next are F(k) and F(k+1) for the digit at index k of the current codeword,
+	// with 0 standing for a Fibonacci number beyond int64
+	value, prev := int64(0), byte('0')
+	f, next := int64(1), int64(2)
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '1' && prev == '1':
+			res = append(res, value)
+			value, prev, f, next = 0, '0', 1, 2
+			continue
+		case s[i] == '1':
+			if f == 0 || value > math.MaxInt64-f {
+				return nil, errors.New("fibonacci codeword exceeds the int64 range")
+			}
+			value += f
+		case s[i] != '0':
+			return nil, errors.New("fibonacci code contains a character other than 0 or 1")
+		}
+		prev = s[i]
+		sum := int64(0)
+		if f != 0 && next != 0 && next <= math.MaxInt64-f {
+			sum = f + next
+		}
+		f, next = next, sum
+	}
+	if f != 1 {
+		return nil, errors.New("fibonacci code ends in an incomplete codeword")
+	}
+	return res, nil
+}
@@ -0,0 +1,91 @@
+package eulerlib
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestZeckendorf(t *testing.T) {
+	testCases := []struct {
+		n    int64
+		want []int64
+	}{
+		{0, []int64{}},
+		{1, []int64{1}},
+		{4, []int64{3, 1}},
+		{64, []int64{55, 8, 1}},
+		{100, []int64{89, 8, 3}},
+	}
+	for _, tc := range testCases {
+		if got := Zeckendorf(tc.n); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Zeckendorf(%d) = %v, want %v", tc.n, got, tc.want)
+		}
+	}
+	for n := int64(0); n <= 5000; n++ {
+		indices := ZeckendorfIndices(n)
+		for i := 1; i < len(indices); i++ {
+			if indices[i-1]-indices[i] < 2 {
+				t.Fatalf("ZeckendorfIndices(%d) = %v uses consecutive Fibonacci numbers", n, indices)
+			}
+		}
+		if got := FromZeckendorfIndices(indices); got != n {
+			t.Fatalf("FromZeckendorfIndices(ZeckendorfIndices(%d)) = %d", n, got)
+		}
+	}
+}
+
+func TestZeckendorfTermCountSum(t *testing.T) {
+	sum := int64(0)
+	for n := int64(0); n <= 3000; n++ {
+		if got := ZeckendorfTermCountSum(n); got != sum {
+			t.Fatalf("ZeckendorfTermCountSum(%d) = %d, want %d", n, got, sum)
+		}
+		sum += int64(ZeckendorfTermCount(n))
+	}
+	if got := ZeckendorfTermCountSum(1000000); got != 7894453 {
+		t.Errorf("ZeckendorfTermCountSum(10^6) = %d, want 7894453", got)
+	}
+}
+
+func TestFibonacciCode(t *testing.T) {
+	want := []string{"", "11", "011", "0011", "1011", "00011", "10011", "01011", "000011", "100011", "010011", "001011"}
+	for n := 1; n < len(want); n++ {
+		if got := FibonacciCode(int64(n)); got != want[n] {
+			t.Errorf("FibonacciCode(%d) = %s, want %s", n, got, want[n])
+		}
+	}
+	code := ""
+	values := []int64{}
+	for n := int64(1); n <= 1000; n += 7 {
+		code += FibonacciCode(n)
+		values = append(values, n)
+	}
+	got, err := DecodeFibonacciCode(code)
+	if err != nil || !reflect.DeepEqual(got, values) {
+		t.Errorf("DecodeFibonacciCode returned %v, %v, want %v", got, err, values)
+	}
+	if _, err := DecodeFibonacciCode("0110"); err == nil {
+		t.Errorf("DecodeFibonacciCode(\"0110\") did not report the incomplete codeword")
+	}
+	if _, err := DecodeFibonacciCode("0121"); err == nil {
+		t.Errorf("DecodeFibonacciCode(\"0121\") did not report the invalid character")
+	}
+
+	// the largest values round trip, anything beyond int64 is an error
+	code = FibonacciCode(math.MaxInt64) + FibonacciCode(1)
+	if got, err := DecodeFibonacciCode(code); err != nil || !reflect.DeepEqual(got, []int64{math.MaxInt64, 1}) {
+		t.Errorf("DecodeFibonacciCode(MaxInt64, 1) = %v, %v, want [%d 1]", got, err, int64(math.MaxInt64))
+	}
+	tooLarge := []string{
+		strings.Repeat("0", 91) + "11",                     // F(93)
+		strings.Repeat("0", 88) + "1011",                   // F(90) + F(92)
+		strings.Repeat("0", 300) + "11" + FibonacciCode(5), // F(302)
+	}
+	for _, c := range tooLarge {
+		if got, err := DecodeFibonacciCode(c); err == nil {
+			t.Errorf("DecodeFibonacciCode(%s) = %v, want an error", c, got)
+		}
+	}
+}